@@ -0,0 +1,47 @@
+package dot
+
+// Accessor gets and sets values using the paths and behavior configured by the options given to New.  The
+// package-level functions use an Accessor with the default options.  Accessors are safe for concurrent use.
+type Accessor struct {
+	options *options
+}
+
+// std is the Accessor behind the package-level functions
+var std = New()
+
+// New returns an Accessor configured with opts, for instance:
+//
+//	colons := dot.New(dot.WithSeparator(":"))
+//	revision, err := colons.Get(obj, "metadata:annotations:deployment.kubernetes.io/revision")
+//
+// New panics if the options are invalid, such as an unusable separator.  Functions that take options along with the
+// path they act on, such as Set, return an error for invalid options instead.
+func New(opts ...Option) *Accessor {
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		panic("dot: " + err.Error())
+	}
+	return &Accessor{options: o}
+}
+
+// with returns an Accessor with opts applied on top of a's options, or a itself when there are none.  It returns an
+// error if the resulting options are invalid.
+func (a *Accessor) with(opts []Option) (*Accessor, error) {
+	if len(opts) == 0 {
+		return a, nil
+	}
+
+	o := *a.options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &Accessor{options: &o}, nil
+}
+
+// parse breaks prop into segments using the Accessor's separator
+func (a *Accessor) parse(prop string) ([]segment, error) {
+	return parsePath(prop, a.options.separator)
+}
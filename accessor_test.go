@@ -0,0 +1,149 @@
+package dot
+
+import "testing"
+
+func TestNew_WithSeparator(t *testing.T) {
+	slash := New(WithSeparator("/"))
+
+	obj := map[string]interface{}{}
+	if err := slash.Set(obj, "metadata/annotations/deployment.kubernetes.io", "3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := slash.Set(obj, "metadata/labels/app\\/name", "web"); err != nil {
+		t.Fatal(err)
+	}
+	if err := slash.Set(obj, "spec/replicas", 2.0); err != nil {
+		t.Fatal(err)
+	}
+
+	annotations := obj["metadata"].(map[string]interface{})["annotations"].(map[string]interface{})
+	if annotations["deployment.kubernetes.io"] != "3" {
+		t.Fatal("key full of periods was not set as a single key")
+	}
+
+	if slash.GetString(obj, "metadata/annotations/deployment.kubernetes.io") != "3" {
+		t.Fatal("GetString did not use the separator")
+	}
+	if slash.GetInt64(obj, "metadata/annotations/deployment.kubernetes.io") != 3 {
+		t.Fatal("GetInt64 did not use the separator")
+	}
+	if slash.GetFloat64(obj, "spec/replicas") != 2 {
+		t.Fatal("GetFloat64 did not use the separator")
+	}
+	if v, err := slash.Get(obj, "missing", "metadata/labels/[\"app/name\"]"); err != nil || v != "web" {
+		t.Fatal("Get did not use the separator")
+	}
+
+	// keys are joined with the separator, and keys containing it are quoted
+	leaves := slash.KeysRecursiveLeaves(obj)
+	if len(leaves) != 3 || !contains(leaves, "metadata/annotations/deployment.kubernetes.io") ||
+		!contains(leaves, "metadata/labels[\"app/name\"]") || !contains(leaves, "spec/replicas") {
+		t.Fatal("KeysRecursiveLeaves did not use the separator")
+	}
+	if !contains(slash.KeysRecursive(obj), "metadata/labels") {
+		t.Fatal("KeysRecursive did not use the separator")
+	}
+	if !contains(slash.Keys(obj["metadata"], "metadata"), "metadata/annotations") {
+		t.Fatal("Keys did not use the separator")
+	}
+
+	to := map[string]interface{}{}
+	if err := slash.Extend(to, obj); err != nil {
+		t.Fatal(err)
+	}
+	if slash.GetString(to, "metadata/labels/app\\/name") != "web" {
+		t.Fatal("Extend did not use the separator")
+	}
+
+	// recursive descent is the separator twice
+	matches, err := slash.GetAll(obj, "//replicas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Path != "spec/replicas" {
+		t.Fatal("GetAll did not use the separator")
+	}
+
+	p, err := slash.Compile("spec/replicas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := p.Get(obj); err != nil || v != 2.0 {
+		t.Fatal("Compile did not use the separator")
+	}
+}
+
+func TestNew_WithSeparatorFilters(t *testing.T) {
+	colons := New(WithSeparator(":"))
+
+	data := map[string]interface{}{
+		"pods": []interface{}{
+			map[string]interface{}{"meta": map[string]interface{}{"ready.count": 1}, "name": "a"},
+			map[string]interface{}{"meta": map[string]interface{}{"ready.count": 0}, "name": "b"},
+		},
+	}
+
+	matches, err := colons.GetAll(data, "pods[?meta:ready.count > 0]:name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Value != "a" || matches[0].Path != "pods:0:name" {
+		t.Fatal("filter operand did not use the separator")
+	}
+}
+
+func TestNew_InvalidSeparator(t *testing.T) {
+	for _, sep := range []string{"", "[", "\\", " ", "*"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("New did not panic for separator " + sep)
+				}
+			}()
+			New(WithSeparator(sep))
+		}()
+	}
+}
+
+func TestOptions_InvalidSeparatorIsAnError(t *testing.T) {
+	obj := map[string]interface{}{"a": 1}
+	bad := WithSeparator("")
+
+	if err := Set(obj, "a", 2, bad); err == nil {
+		t.Error("Set did not return an error")
+	}
+	if _, err := Delete(obj, "a", bad); err == nil {
+		t.Error("Delete did not return an error")
+	}
+	if err := Move(obj, "a", "b", bad); err == nil {
+		t.Error("Move did not return an error")
+	}
+	if err := Copy(obj, "a", "b", bad); err == nil {
+		t.Error("Copy did not return an error")
+	}
+	if err := SetPointer(obj, "/a", 2, bad); err == nil {
+		t.Error("SetPointer did not return an error")
+	}
+	if _, err := GetPointer(obj, "/a", bad); err == nil {
+		t.Error("GetPointer did not return an error")
+	}
+	if _, err := CompilePointer("/a", bad); err == nil {
+		t.Error("CompilePointer did not return an error")
+	}
+	if err := MustCompile("a").Set(obj, 2, bad); err == nil {
+		t.Error("Path.Set did not return an error")
+	}
+	if obj["a"] != 1 || len(obj) != 1 {
+		t.Error("obj was changed, got", obj)
+	}
+}
+
+func TestSet_WithSeparatorOption(t *testing.T) {
+	obj := map[string]interface{}{}
+	if err := Set(obj, "a/b.c", 1, WithSeparator("/")); err != nil {
+		t.Fatal(err)
+	}
+	if GetInt64(obj, "a[\"b.c\"]") != 1 {
+		t.Fatal("Set did not honor the separator option")
+	}
+}
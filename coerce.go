@@ -0,0 +1,119 @@
+package dot
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Coercer converts value to the type it was registered to produce with RegisterCoercion, or returns an error if value
+// can't be converted
+type Coercer func(value interface{}) (interface{}, error)
+
+// coercionKey identifies a registered Coercer by the types it converts between
+type coercionKey struct {
+	from reflect.Type
+	to   reflect.Type
+}
+
+var coercions sync.Map
+
+// RegisterCoercion makes fn the way values of type from are converted to type to.  The Coerce functions (and so the
+// typed getters) consult it for their own result types, such as string for CoerceString and int64 for CoerceInt64, and
+// GetAs consults it for the type requested.  Set uses it whenever a value of type from can't be assigned to a field
+// of type to as it is, even without WithConversion, and so does Extend.  Pointers to from are converted by fn too,
+// unless a Coercer is registered for the pointer type itself.  A nil fn removes the registration.  fn may return
+// anything convertible to type to.
+//
+// Without a registration, values of types implementing encoding.TextMarshaler, fmt.Stringer, driver.Valuer or
+// json.Marshaler are coerced through what those methods return, tried in that order until one can be coerced.
+func RegisterCoercion(from, to reflect.Type, fn Coercer) {
+	key := coercionKey{from: from, to: to}
+	if fn == nil {
+		coercions.Delete(key)
+		return
+	}
+	coercions.Store(key, fn)
+}
+
+// registeredCoercion converts obj to t with the Coercer registered for them, returning whether there is one
+func registeredCoercion(obj interface{}, t reflect.Type) (reflect.Value, bool, error) {
+	val := reflect.ValueOf(obj)
+	if !val.IsValid() {
+		return val, false, nil
+	}
+
+	fn, ok := coercions.Load(coercionKey{from: val.Type(), to: t})
+	if !ok && val.Kind() == reflect.Ptr && !val.IsNil() {
+		if fn, ok = coercions.Load(coercionKey{from: val.Type().Elem(), to: t}); ok {
+			obj = val.Elem().Interface()
+		}
+	}
+	if !ok {
+		return val, false, nil
+	}
+
+	converted, err := fn.(Coercer)(obj)
+	if err != nil {
+		return val, true, &ConversionError{Value: obj, Type: t, Reason: err.Error(), Err: err}
+	}
+
+	out := reflect.ValueOf(converted)
+	if !out.IsValid() || !out.Type().ConvertibleTo(t) {
+		return val, true, &ConversionError{Value: obj, Type: t,
+			Reason: fmt.Sprintf("the registered coercion returned %T", converted)}
+	}
+	return out.Convert(t), true, nil
+}
+
+// registeredAs does what registeredCoercion does for the Coerce functions, which report failure with a bool
+func registeredAs[T any](obj interface{}) (T, bool, bool) {
+	var zero T
+	converted, registered, err := registeredCoercion(obj, reflect.TypeOf(&zero).Elem())
+	if !registered || err != nil {
+		return zero, registered, false
+	}
+	return converted.Interface().(T), true, true
+}
+
+// coerceMarshaled calls try with each of the values obj stands for through the encoding.TextMarshaler, fmt.Stringer,
+// driver.Valuer and json.Marshaler interfaces it implements, in that order, until try returns true.  It returns false
+// if obj implements none of them or try never returns true.
+func coerceMarshaled(obj interface{}, try func(interface{}) bool) bool {
+	val := reflect.ValueOf(obj)
+	if !val.IsValid() || ((val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface) && val.IsNil()) {
+		return false
+	}
+
+	if m, ok := obj.(encoding.TextMarshaler); ok {
+		if text, err := m.MarshalText(); err == nil && try(string(text)) {
+			return true
+		}
+	}
+
+	if s, ok := obj.(fmt.Stringer); ok && try(s.String()) {
+		return true
+	}
+
+	if v, ok := obj.(driver.Valuer); ok {
+		if value, err := v.Value(); err == nil && value != nil && try(value) {
+			return true
+		}
+	}
+
+	if m, ok := obj.(json.Marshaler); ok {
+		if data, err := m.MarshalJSON(); err == nil {
+			var decoded interface{}
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.UseNumber()
+			if decoder.Decode(&decoded) == nil && decoded != nil && try(decoded) {
+				return true
+			}
+		}
+	}
+	return false
+}
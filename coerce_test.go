@@ -0,0 +1,180 @@
+package dot
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testStatus int
+
+func (s testStatus) String() string {
+	return [...]string{"inactive", "active"}[s]
+}
+
+type testMoney struct {
+	cents int64
+}
+
+func (m testMoney) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d.%02d", m.cents/100, m.cents%100)), nil
+}
+
+type testUUID [4]byte
+
+func (u *testUUID) String() string {
+	return fmt.Sprintf("%x", u[:])
+}
+
+type testSerial struct {
+	n int64
+}
+
+func (s testSerial) Value() (driver.Value, error) {
+	return s.n, nil
+}
+
+type testFlag struct{}
+
+func (testFlag) MarshalJSON() ([]byte, error) {
+	return []byte(`"on"`), nil
+}
+
+type testCents int64
+
+var errBadCents = errors.New("bad cents")
+
+func TestCoerce_Marshalers(t *testing.T) {
+	if s, ok := CoerceString(testStatus(1)); !ok || s != "active" {
+		t.Error("Stringer was not used, got", s)
+	}
+	if i, ok := CoerceInt64(testStatus(1)); !ok || i != 1 {
+		t.Error("enum did not keep its number, got", i)
+	}
+	if s, ok := CoerceString(testMoney{1234}); !ok || s != "12.34" {
+		t.Error("TextMarshaler was not used, got", s)
+	}
+	if f, ok := CoerceFloat64(testMoney{1234}); !ok || f != 12.34 {
+		t.Error("TextMarshaler was not used for a number, got", f)
+	}
+	if s, ok := CoerceString(&testUUID{0xde, 0xad, 0xbe, 0xef}); !ok || s != "deadbeef" {
+		t.Error("Stringer was not used on a pointer, got", s)
+	}
+	if u, ok := CoerceUint64(testSerial{40}); !ok || u != 40 {
+		t.Error("driver.Valuer was not used, got", u)
+	}
+	if b, ok := CoerceBool(testFlag{}); !ok || !b {
+		t.Error("json.Marshaler was not used")
+	}
+	if d, ok := CoerceDuration(testSerial{30}); !ok || d != 30*time.Second {
+		t.Error("driver.Valuer was not used for a duration, got", d)
+	}
+	if s, ok := CoerceString(time.Duration(1500) * time.Millisecond); !ok || s != "1.5s" {
+		t.Error("Duration was not rendered by its String method, got", s)
+	}
+
+	var nilUUID *testUUID
+	if _, ok := CoerceString(nilUUID); ok {
+		t.Error("coerced a nil pointer")
+	}
+	if _, ok := CoerceInt64(testMoney{1250}); ok {
+		t.Error("coerced 12.50 to an integer")
+	}
+
+	data := map[string]interface{}{"status": testStatus(0), "price": testMoney{99}}
+	if GetString(data, "status") != "inactive" {
+		t.Error("GetString did not use the Stringer")
+	}
+	if GetString(data, "price") != "0.99" {
+		t.Error("GetString did not use the TextMarshaler")
+	}
+
+	var target struct {
+		Status string
+		Price  float64
+		Serial int
+	}
+	converting := New(WithConversion())
+	if err := converting.Extend(&target, map[string]interface{}{
+		"Status": testStatus(1),
+		"Price":  testMoney{250},
+		"Serial": testSerial{20},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if target.Status != "active" || target.Price != 2.5 || target.Serial != 20 {
+		t.Error("converting Extend did not use the marshalers, got", target)
+	}
+}
+
+func TestRegisterCoercion(t *testing.T) {
+	centsType := reflect.TypeOf(testCents(0))
+	stringType := reflect.TypeOf("")
+
+	RegisterCoercion(centsType, stringType, func(v interface{}) (interface{}, error) {
+		c := v.(testCents)
+		return fmt.Sprintf("$%d.%02d", c/100, c%100), nil
+	})
+	RegisterCoercion(stringType, centsType, func(v interface{}) (interface{}, error) {
+		dollars, err := strconv.ParseFloat(strings.TrimPrefix(v.(string), "$"), 64)
+		if err != nil {
+			return nil, errBadCents
+		}
+		return int64(dollars*100 + 0.5), nil
+	})
+	defer RegisterCoercion(centsType, stringType, nil)
+	defer RegisterCoercion(stringType, centsType, nil)
+
+	price := testCents(1999)
+	if s, ok := CoerceString(price); !ok || s != "$19.99" {
+		t.Error("registered coercion was not used, got", s)
+	}
+	if s, ok := CoerceString(&price); !ok || s != "$19.99" {
+		t.Error("registered coercion was not used for a pointer, got", s)
+	}
+	if GetString(map[string]interface{}{"price": price}, "price") != "$19.99" {
+		t.Error("GetString did not use the registered coercion")
+	}
+	if i, ok := CoerceInt64(price); !ok || i != 1999 {
+		t.Error("coercion to another type was affected, got", i)
+	}
+
+	if c, err := GetAs[testCents](map[string]interface{}{"price": "$5.25"}, "price"); err != nil || c != 525 {
+		t.Error("GetAs did not use the registered coercion, got", c, err)
+	}
+
+	// Set uses registered coercions even without WithConversion
+	var item struct {
+		Price testCents
+		Label string
+	}
+	if err := Set(&item, "Price", "$3.10"); err != nil || item.Price != 310 {
+		t.Error("Set did not use the registered coercion, got", item.Price, err)
+	}
+	if err := Set(&item, "Label", testCents(42)); err != nil || item.Label != "$0.42" {
+		t.Error("Set did not use the registered coercion, got", item.Label, err)
+	}
+
+	err := Set(&item, "Price", "free")
+	if !errors.Is(err, errBadCents) || !errors.Is(err, ErrTypeMismatch) {
+		t.Error("coercer error was not kept, got", err)
+	}
+	var conversion *ConversionError
+	if !errors.As(err, &conversion) || conversion.Type != centsType {
+		t.Error("expected a ConversionError, got", err)
+	}
+
+	if err := Extend(&item, map[string]interface{}{"Price": "$7.00"}); err != nil || item.Price != 700 {
+		t.Error("Extend did not use the registered coercion, got", item.Price, err)
+	}
+
+	RegisterCoercion(centsType, stringType, nil)
+	if s, ok := CoerceString(price); !ok || s != "1999" {
+		t.Error("removed coercion was still used, got", s)
+	}
+}
@@ -0,0 +1,102 @@
+package dot
+
+// Path is a parsed path, which can be applied to any number of objects without being parsed again.  Paths are safe
+// for concurrent use.
+type Path struct {
+	prop     string
+	segments []segment
+	accessor *Accessor
+}
+
+// Compile parses prop into a Path, returning an error if prop isn't a valid path.  Compiling paths up front reports
+// syntax errors once (typically at startup), and avoids parsing the same path each time it is used.
+func Compile(prop string) (*Path, error) {
+	return std.Compile(prop)
+}
+
+// Compile does what the package-level Compile does, using the Accessor's options.  The returned Path keeps using
+// them.
+func (a *Accessor) Compile(prop string) (*Path, error) {
+	segments, err := a.parse(prop)
+	if err != nil {
+		return nil, err
+	}
+	return &Path{prop: prop, segments: segments, accessor: a}, nil
+}
+
+// MustCompile is like Compile, but panics if prop isn't a valid path.  It is intended for package-level variables.
+func MustCompile(prop string) *Path {
+	p, err := Compile(prop)
+	if err != nil {
+		panic("dot: Compile(" + prop + "): " + err.Error())
+	}
+	return p
+}
+
+// Get returns the value in obj at the path, just as the package-level Get does for a single property
+func (p *Path) Get(obj interface{}) (interface{}, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	v, err := getPath(obj, p.segments, p.accessor.options)
+	return v, withPath(err, p.prop)
+}
+
+// GetAll returns every value in obj matching the path, just as the package-level GetAll does
+func (p *Path) GetAll(obj interface{}) ([]Match, error) {
+	matches, err := p.accessor.getAllPath(obj, p.segments)
+	return matches, withPath(err, p.prop)
+}
+
+// Set applies value at the path in obj, just as the package-level Set does, so it fails for paths that Set rejects,
+// such as those ending in a separator
+func (p *Path) Set(obj interface{}, value interface{}, opts ...Option) error {
+	if err := p.checkWritable("set"); err != nil {
+		return err
+	}
+	a, err := p.accessor.with(opts)
+	if err != nil {
+		return err
+	}
+	return withPath(a.setPath(obj, p.segments, value), p.prop)
+}
+
+// Delete removes whatever is at the path in obj, just as the package-level Delete does, so it fails for paths that
+// Delete rejects, such as those ending in a separator
+func (p *Path) Delete(obj interface{}, opts ...Option) (bool, error) {
+	if err := p.checkWritable("delete"); err != nil {
+		return false, err
+	}
+	a, err := p.accessor.with(opts)
+	if err != nil {
+		return false, err
+	}
+	removed, err := a.deletePath(obj, p.segments)
+	return removed, withPath(err, p.prop)
+}
+
+// Lookup returns the value in obj at the path and whether it was found, just as the package-level Lookup does
+func (p *Path) Lookup(obj interface{}) (interface{}, bool, error) {
+	v, found, err := lookupPath(obj, p.segments, p.accessor.options)
+	return v, found, withPath(err, p.prop)
+}
+
+// Exists returns true if Lookup finds the path in obj, even if the value there is nil
+func (p *Path) Exists(obj interface{}) bool {
+	_, found, err := p.Lookup(obj)
+	return found && err == nil
+}
+
+// checkWritable does what Accessor.checkWritable does for the path, unless it is a JSON Pointer, whose separator is
+// always "/" and whose keys may begin or end with anything
+func (p *Path) checkWritable(op string) error {
+	if len(p.segments) > 0 && p.segments[0].pointer {
+		return nil
+	}
+	return p.accessor.checkWritable(p.prop, op)
+}
+
+// String returns the path as it was given to Compile
+func (p *Path) String() string {
+	return p.prop
+}
@@ -0,0 +1,133 @@
+package dot
+
+import "testing"
+
+func TestCompile(t *testing.T) {
+	p, err := Compile("a.b[0].c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.String() != "a.b[0].c" {
+		t.Fatal("String did not return the compiled path")
+	}
+
+	records := []map[string]interface{}{{}, {}}
+	for i, record := range records {
+		if err := p.Set(record, i+1, GrowSlices()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, record := range records {
+		v, err := p.Get(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != i+1 {
+			t.Fatal("did not get back what was set with a compiled path")
+		}
+		if !p.Exists(record) {
+			t.Fatal("Exists was false for a path that was set")
+		}
+	}
+
+	if p.Exists(map[string]interface{}{"a": map[string]interface{}{}}) {
+		t.Fatal("Exists was true for a missing path")
+	}
+
+	if v, err := p.Get(nil); v != nil || err != nil {
+		t.Fatal("Get on nil should return nil, nil")
+	}
+}
+
+func TestCompile_Wildcards(t *testing.T) {
+	p := MustCompile("orders[*].total")
+
+	matches, err := p.GetAll(map[string]interface{}{
+		"orders": []interface{}{
+			map[string]interface{}{"total": 3},
+			map[string]interface{}{"total": 4},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Fatal("compiled wildcard path did not match both orders")
+	}
+
+	if err := p.Set(map[string]interface{}{}, 1); err == nil {
+		t.Fatal("did not get an error setting a wildcard path")
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	if _, err := Compile("a[\"b"); err == nil {
+		t.Fatal("did not get an error compiling an invalid path")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustCompile did not panic on an invalid path")
+		}
+	}()
+	MustCompile("a[?]")
+}
+
+func TestCompile_WritingChecksSeparators(t *testing.T) {
+	for _, prop := range []string{"a.", ".a"} {
+		m := map[string]interface{}{}
+		if err := MustCompile(prop).Set(m, 1); err == nil {
+			t.Error("compiled", prop, "was set, giving", m)
+		}
+		if _, err := MustCompile(prop).Delete(m); err == nil {
+			t.Error("compiled", prop, "was deleted from")
+		}
+	}
+
+	p, err := CompilePointer("/a.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := map[string]interface{}{}
+	if err := p.Set(m, 1); err != nil || m["a."] != 1 {
+		t.Error("compiled pointer to a key ending in a period was not set:", err)
+	}
+
+	colons, err := New(WithSeparator(":")).Compile("a:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := colons.Set(map[string]interface{}{}, 1); err == nil {
+		t.Error("path ending in the accessor's separator was set")
+	}
+}
+
+func BenchmarkGet_Parsed(b *testing.B) {
+	data := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": []interface{}{
+				map[string]interface{}{"c": 1},
+			},
+		},
+	}
+
+	for i := 0; i < b.N; i++ {
+		_, _ = Get(data, "a.b[0].c")
+	}
+}
+
+func BenchmarkGet_Compiled(b *testing.B) {
+	data := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": []interface{}{
+				map[string]interface{}{"c": 1},
+			},
+		},
+	}
+
+	p := MustCompile("a.b[0].c")
+	for i := 0; i < b.N; i++ {
+		_, _ = p.Get(data)
+	}
+}
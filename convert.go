@@ -0,0 +1,190 @@
+package dot
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// ConversionError is returned by a converting Set (see WithConversion) when Value can't be converted to Type, either
+// because there's no conversion between them or because converting would lose data.  Reason says which.  Err is the
+// error returned by a registered Coercer (see RegisterCoercion), if it failed.
+type ConversionError struct {
+	Value  interface{}
+	Type   reflect.Type
+	Reason string
+	Err    error
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("cannot convert %v (%T) to %s: %s", e.Value, e.Value, e.Type, e.Reason)
+}
+
+// Is makes errors.Is match the error to ErrTypeMismatch
+func (e *ConversionError) Is(target error) bool {
+	return target == ErrTypeMismatch
+}
+
+// Unwrap returns the error returned by a registered Coercer, if any
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+// convertValue converts val to the type t for a converting Set.  Strings, numbers and bools are converted into fields
+// of any of those kinds, using the Coerce functions where they apply.  Integers must fit their destination, floats
+// must be whole numbers to become integers, and bools are read as CoerceBool reads them.  Pointers are allocated to
+// hold the converted value, and anything else convertible by the reflect package (such as a string to a named string
+// type) is converted as reflect does.  time.Time and time.Duration are read as CoerceTime and CoerceDuration read them,
+// so numbers become durations in seconds.  A Coercer registered for the types is used in place of all of that, and types
+// implementing the interfaces RegisterCoercion detects are converted through them.
+func convertValue(val reflect.Value, t reflect.Type) (reflect.Value, error) {
+	value := val.Interface()
+	if converted, registered, err := registeredCoercion(value, t); registered {
+		return converted, err
+	}
+
+	fail := func(reason string) (reflect.Value, error) {
+		return val, &ConversionError{Value: value, Type: t, Reason: reason}
+	}
+
+	switch t {
+	case timeType:
+		tm, ok := CoerceTime(value)
+		if !ok {
+			return fail("it is not a time")
+		}
+		return reflect.ValueOf(tm), nil
+	case durationType:
+		d, ok := CoerceDuration(value)
+		if !ok {
+			return fail("it is not a duration")
+		}
+		return reflect.ValueOf(d), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, reason := strictInt64(value)
+		if reason != "" {
+			return fail(reason)
+		}
+		out := reflect.New(t).Elem()
+		if out.OverflowInt(i) {
+			return fail("it is out of range")
+		}
+		out.SetInt(i)
+		return out, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, reason := strictUint64(value)
+		if reason != "" {
+			return fail(reason)
+		}
+		out := reflect.New(t).Elem()
+		if out.OverflowUint(u) {
+			return fail("it is out of range")
+		}
+		out.SetUint(u)
+		return out, nil
+	case reflect.Float32, reflect.Float64:
+		f, ok := CoerceFloat64(value)
+		if !ok {
+			return fail("it is not a number")
+		}
+		out := reflect.New(t).Elem()
+		if out.OverflowFloat(f) {
+			return fail("it is out of range")
+		}
+		out.SetFloat(f)
+		return out, nil
+	case reflect.Bool:
+		if isText(val) || isNumeric(val) {
+			b, ok := CoerceBool(textOrValue(val))
+			if !ok {
+				return fail("it is not a boolean")
+			}
+			return reflect.ValueOf(b).Convert(t), nil
+		}
+		if b, ok := CoerceBool(value); ok {
+			return reflect.ValueOf(b).Convert(t), nil
+		}
+	case reflect.String:
+		if isText(val) {
+			return reflect.ValueOf(textOf(val)).Convert(t), nil
+		}
+		var marshaled string
+		if coerceMarshaled(value, func(v interface{}) bool {
+			var ok bool
+			marshaled, ok = CoerceString(v)
+			return ok
+		}) {
+			return reflect.ValueOf(marshaled).Convert(t), nil
+		}
+		if s, ok := formatScalar(val); ok {
+			return reflect.ValueOf(s).Convert(t), nil
+		}
+	case reflect.Ptr:
+		elem, err := convertValue(val, t.Elem())
+		if err != nil {
+			return val, err
+		}
+		out := reflect.New(t.Elem())
+		out.Elem().Set(elem)
+		return out, nil
+	}
+
+	if val.Type().ConvertibleTo(t) {
+		return val.Convert(t), nil
+	}
+	return fail("there is no conversion between the types")
+}
+
+// formatScalar renders the number or bool val as a string
+func formatScalar(val reflect.Value) (string, bool) {
+	switch {
+	case val.Kind() >= reflect.Int && val.Kind() <= reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10), true
+	case val.Kind() >= reflect.Uint && val.Kind() <= reflect.Uintptr:
+		return strconv.FormatUint(val.Uint(), 10), true
+	case val.Kind() == reflect.Float32:
+		return strconv.FormatFloat(val.Float(), 'f', -1, 32), true
+	case val.Kind() == reflect.Float64 || val.Kind() == reflect.Bool:
+		return CoerceString(textOrValue(val))
+	}
+	return "", false
+}
+
+// isNumeric returns true if val is an integer or a float
+func isNumeric(val reflect.Value) bool {
+	k := val.Kind()
+	return (k >= reflect.Int && k <= reflect.Uintptr) || k == reflect.Float32 || k == reflect.Float64
+}
+
+// isText returns true if val is a string or a []byte
+func isText(val reflect.Value) bool {
+	return val.Kind() == reflect.String || (val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.Uint8)
+}
+
+// textOf returns the string or []byte val as a string
+func textOf(val reflect.Value) string {
+	if val.Kind() == reflect.String {
+		return val.String()
+	}
+	return string(val.Bytes())
+}
+
+// textOrValue prepares val for the Coerce functions, which only know unnamed types
+func textOrValue(val reflect.Value) interface{} {
+	switch {
+	case isText(val):
+		return textOf(val)
+	case val.Kind() >= reflect.Int && val.Kind() <= reflect.Int64:
+		return val.Int()
+	case val.Kind() >= reflect.Uint && val.Kind() <= reflect.Uintptr:
+		return float64(val.Uint())
+	case val.Kind() == reflect.Float32 || val.Kind() == reflect.Float64:
+		return val.Float()
+	case val.Kind() == reflect.Bool:
+		return val.Bool()
+	}
+	return val.Interface()
+}
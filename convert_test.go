@@ -0,0 +1,93 @@
+package dot
+
+import (
+	"errors"
+	"testing"
+)
+
+type convertConfig struct {
+	Port    int
+	Small   int8
+	Count   uint
+	Ratio   float32
+	Debug   bool
+	Name    string
+	Level   convertLevel
+	Timeout *int
+}
+
+type convertLevel string
+
+func TestWithConversion_Set(t *testing.T) {
+	c := convertConfig{}
+	converting := New(WithConversion())
+
+	if err := converting.Set(&c, "Port", "8080"); err != nil || c.Port != 8080 {
+		t.Fatal("string was not converted to int:", c.Port, err)
+	}
+	if err := converting.Set(&c, "Port", float64(9090)); err != nil || c.Port != 9090 {
+		t.Fatal("float64 was not converted to int:", c.Port, err)
+	}
+	if err := converting.Set(&c, "Count", "1e3"); err != nil || c.Count != 1000 {
+		t.Fatal("string float was not converted to uint:", c.Count, err)
+	}
+	if err := converting.Set(&c, "Ratio", "0.5"); err != nil || c.Ratio != 0.5 {
+		t.Fatal("string was not converted to float32:", c.Ratio, err)
+	}
+	if err := converting.Set(&c, "Debug", "true"); err != nil || !c.Debug {
+		t.Fatal("string was not converted to bool:", c.Debug, err)
+	}
+	if err := converting.Set(&c, "Debug", 0); err != nil || c.Debug {
+		t.Fatal("0 was not converted to bool:", c.Debug, err)
+	}
+	if err := converting.Set(&c, "Name", 42); err != nil || c.Name != "42" {
+		t.Fatal("int was not converted to string:", c.Name, err)
+	}
+	if err := converting.Set(&c, "Level", "debug"); err != nil || c.Level != "debug" {
+		t.Fatal("string was not converted to a named string type:", c.Level, err)
+	}
+	if err := converting.Set(&c, "Timeout", "30"); err != nil || c.Timeout == nil || *c.Timeout != 30 {
+		t.Fatal("string was not converted to *int:", err)
+	}
+
+	// the option may also be given to a single Set
+	if err := Set(&c, "Port", "7070", WithConversion()); err != nil || c.Port != 7070 {
+		t.Fatal("per-call option did not convert:", c.Port, err)
+	}
+
+	// without the option, nothing is converted
+	if err := Set(&c, "Port", "8080"); err == nil {
+		t.Fatal("expected an error setting a string into an int without conversion")
+	}
+}
+
+func TestWithConversion_Lossy(t *testing.T) {
+	c := convertConfig{}
+	converting := New(WithConversion())
+
+	for _, test := range []struct {
+		prop  string
+		value interface{}
+	}{
+		{"Port", 1.5},
+		{"Port", "8080.5"},
+		{"Port", "eighty"},
+		{"Small", 300},
+		{"Small", "-129"},
+		{"Count", -1},
+		{"Ratio", 1e40},
+		{"Debug", 2},
+		{"Debug", "maybe"},
+		{"Name", []int{1}},
+	} {
+		err := converting.Set(&c, test.prop, test.value)
+		var convErr *ConversionError
+		if !errors.As(err, &convErr) {
+			t.Error("expected a *ConversionError setting", test.prop, "to", test.value, "but got", err)
+		}
+	}
+
+	if c.Port != 0 || c.Small != 0 {
+		t.Fatal("a failed conversion changed the field")
+	}
+}
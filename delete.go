@@ -0,0 +1,220 @@
+package dot
+
+import (
+	"reflect"
+)
+
+// Delete removes whatever is at the position prop in obj, following the same path rules as Set.  A map key is removed
+// from its map, a slice element is removed from its slice (shifting later elements down), and a struct field or array
+// element is set to its zero value, which for a pointer is nil.  It returns true if anything was removed, and false if
+// there was nothing there to remove, such as a missing map key or an index beyond the end of a slice.  Missing nodes
+// along the way are not allocated.
+func Delete(obj interface{}, prop string, opts ...Option) (bool, error) {
+	return std.Delete(obj, prop, opts...)
+}
+
+// Delete does what the package-level Delete does, using the Accessor's options along with any given here
+func (a *Accessor) Delete(obj interface{}, prop string, opts ...Option) (bool, error) {
+	a, err := a.with(opts)
+	if err != nil {
+		return false, err
+	}
+	if obj == nil {
+		return false, notAddressable("obj may not be nil for dot.Delete")
+	}
+
+	segments, err := a.parseWritable(prop, "delete")
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := a.deletePath(obj, segments)
+	return removed, withPath(err, prop)
+}
+
+// deletePath removes whatever is at the location given by segments within obj
+func (a *Accessor) deletePath(obj interface{}, segments []segment) (bool, error) {
+	if obj == nil {
+		return false, notAddressable("obj may not be nil for dot.Delete")
+	}
+
+	for _, seg := range segments {
+		if seg.fansOut() || seg.descend {
+			return false, newPathError(seg, reflect.Invalid,
+				typeMismatch("wildcards, filters and recursive descent may not be used with dot.Delete"))
+		}
+		if seg.kind == appendSegment {
+			return false, newPathError(seg, reflect.Invalid, errAppendOnlyInSet)
+		}
+	}
+
+	root := reflect.ValueOf(obj)
+	switch root.Kind() {
+	case reflect.Map:
+	case reflect.Slice:
+		if len(segments) == 1 {
+			return false, newPathError(segments[0], root.Kind(),
+				notAddressable("slice must be passed as a pointer to delete from it"))
+		}
+	case reflect.Ptr:
+		if root.IsNil() {
+			return false, newPathError(segments[0], root.Kind(), notAddressable("obj may not be nil for dot.Delete"))
+		}
+	default:
+		return false, newPathError(segments[0], root.Kind(), notAddressable("object must be a pointer to a struct"))
+	}
+
+	_, removed, err := deleteValue(root, segments, a.options)
+	return removed, err
+}
+
+// deleteValue removes whatever is at the location given by segments within v.  Like setValue, it returns the updated
+// v, which the caller must store back into wherever v came from if anything was removed, and its errors are
+// *PathErrors.
+func deleteValue(v reflect.Value, segments []segment, o *options) (reflect.Value, bool, error) {
+	updated, removed, err := deleteSegment(v, segments, o)
+	if err != nil {
+		return updated, removed, newPathError(segments[0], containerKind(v, v.Type()), err)
+	}
+	return updated, removed, nil
+}
+
+// deleteSegment does the work of deleteValue for segments[0], leaving its errors for deleteValue to wrap
+func deleteSegment(v reflect.Value, segments []segment, o *options) (reflect.Value, bool, error) {
+	seg := segments[0]
+	key := seg.key
+	last := len(segments) == 1
+
+	// work on whatever an interface holds, which is nothing to remove if it holds nothing
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false, nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return v, false, typeMismatch("cannot delete property " + key + " of a map without string keys")
+		}
+		if v.IsNil() {
+			return v, false, nil
+		}
+
+		mapKey := reflect.ValueOf(key).Convert(v.Type().Key())
+		if o.caseMode == caseInsensitive && !v.MapIndex(mapKey).IsValid() {
+			existing, ok, err := foldMapKey(v, key)
+			if err != nil {
+				return v, false, err
+			}
+			if ok {
+				mapKey = existing
+			}
+		}
+
+		child := v.MapIndex(mapKey)
+		if !child.IsValid() {
+			return v, false, nil
+		}
+
+		if last {
+			v.SetMapIndex(mapKey, reflect.Value{})
+			return v, true, nil
+		}
+
+		updated, removed, err := deleteValue(child, segments[1:], o)
+		if removed {
+			v.SetMapIndex(mapKey, updated)
+		}
+		return v, removed, err
+	case reflect.Slice, reflect.Array:
+		if err := checkPointerIndex(seg, v.Len(), false); err != nil {
+			return v, false, err
+		}
+
+		i, err := resolveIndex(key, v.Len())
+		if err != nil {
+			if _, ok := err.(*IndexOutOfRangeError); ok {
+				return v, false, nil
+			}
+			return v, false, err
+		}
+
+		if !v.CanAddr() && v.Kind() == reflect.Array {
+			v = addressable(v)
+		}
+
+		if last {
+			if v.Kind() == reflect.Array {
+				v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+				return v, true, nil
+			}
+
+			// build a new slice, rather than shifting elements within a backing array that others may share
+			shorter := reflect.MakeSlice(v.Type(), 0, v.Len()-1)
+			shorter = reflect.AppendSlice(shorter, v.Slice(0, i))
+			shorter = reflect.AppendSlice(shorter, v.Slice(i+1, v.Len()))
+			return shorter, true, nil
+		}
+
+		removed, err := deleteElem(v.Index(i), segments, o)
+		return v, removed, err
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v, false, nil
+		}
+
+		// the pointer itself doesn't change, only what it points to
+		elem := v.Elem()
+		updated, removed, err := deleteValue(elem, segments, o)
+		if removed {
+			elem.Set(updated)
+		}
+		return v, removed, err
+	case reflect.Struct:
+		if !v.CanAddr() {
+			v = addressable(v)
+		}
+
+		f, err := findField(v.Type(), key, o)
+		if err != nil {
+			return v, false, err
+		}
+
+		if f.readOnly {
+			return v, false, &ReadOnlyError{Field: f.goName}
+		}
+
+		// a field promoted through a nil embedded pointer has nothing to remove
+		field, ok := fieldValue(v, f.index)
+		if !ok {
+			return v, false, nil
+		}
+		if !field.CanSet() {
+			return v, false, notAddressable("Cannot set " + f.goName + " field value")
+		}
+
+		if last {
+			zero := reflect.Zero(field.Type())
+			if reflect.DeepEqual(field.Interface(), zero.Interface()) {
+				return v, false, nil
+			}
+			field.Set(zero)
+			return v, true, nil
+		}
+		removed, err := deleteElem(field, segments, o)
+		return v, removed, err
+	}
+
+	return v, false, typeMismatch("cannot delete property " + key + " of a value of kind " + v.Kind().String())
+}
+
+// deleteElem removes whatever is at segments[1:] within elem, which must be settable and is what segments[0] addressed
+func deleteElem(elem reflect.Value, segments []segment, o *options) (bool, error) {
+	updated, removed, err := deleteValue(elem, segments[1:], o)
+	if removed {
+		elem.Set(updated)
+	}
+	return removed, err
+}
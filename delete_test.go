@@ -0,0 +1,112 @@
+package dot
+
+import "testing"
+
+func TestDelete_MapKey(t *testing.T) {
+	payload := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":     "ann",
+			"password": "secret",
+		},
+	}
+
+	removed, err := Delete(payload, "user.password")
+	if err != nil || !removed {
+		t.Fatal("map key was not removed:", err)
+	}
+	if _, ok := payload["user"].(map[string]interface{})["password"]; ok {
+		t.Fatal("password is still in the map")
+	}
+
+	// removing it again finds nothing to remove
+	if removed, err := Delete(payload, "user.password"); err != nil || removed {
+		t.Fatal("expected nothing to be removed:", err)
+	}
+
+	// nothing is allocated for missing nodes
+	if removed, err := Delete(payload, "missing.password"); err != nil || removed {
+		t.Fatal("expected nothing to be removed:", err)
+	}
+	if _, ok := payload["missing"]; ok {
+		t.Fatal("Delete allocated a missing node")
+	}
+}
+
+func TestDelete_StructField(t *testing.T) {
+	type Credentials struct {
+		Token string
+	}
+	type Account struct {
+		Name  string
+		Creds *Credentials
+		Inner Credentials
+		ID    int `dot:",readonly"`
+	}
+
+	a := Account{Name: "ann", Creds: &Credentials{Token: "t"}, Inner: Credentials{Token: "u"}, ID: 1}
+
+	if removed, err := Delete(&a, "Inner.Token"); err != nil || !removed || a.Inner.Token != "" {
+		t.Fatal("nested field was not zeroed:", err)
+	}
+	if removed, err := Delete(&a, "Creds"); err != nil || !removed || a.Creds != nil {
+		t.Fatal("pointer field was not set to nil:", err)
+	}
+	if removed, err := Delete(&a, "Creds.Token"); err != nil || removed {
+		t.Fatal("expected nothing to be removed through a nil pointer:", err)
+	}
+	if removed, err := Delete(&a, "Inner.Token"); err != nil || removed {
+		t.Fatal("expected nothing to be removed from a zero field:", err)
+	}
+
+	if _, err := Delete(&a, "ID"); err == nil {
+		t.Fatal("expected an error deleting a read-only field")
+	}
+	if _, err := Delete(&a, "Nope"); err == nil {
+		t.Fatal("expected an error deleting a field that doesn't exist")
+	}
+	if _, err := Delete(a, "Name"); err == nil {
+		t.Fatal("expected an error deleting from a struct passed by value")
+	}
+}
+
+func TestDelete_SliceElement(t *testing.T) {
+	tags := []interface{}{"a", "b", "c"}
+	obj := map[string]interface{}{"tags": tags}
+
+	if removed, err := Delete(obj, "tags.1"); err != nil || !removed {
+		t.Fatal("slice element was not removed:", err)
+	}
+	updated := obj["tags"].([]interface{})
+	if len(updated) != 2 || updated[0] != "a" || updated[1] != "c" {
+		t.Fatal("unexpected slice after removal", updated)
+	}
+	if tags[1] != "b" {
+		t.Fatal("Delete changed the original backing array")
+	}
+
+	if removed, err := Delete(obj, "tags[-1]"); err != nil || !removed || len(obj["tags"].([]interface{})) != 1 {
+		t.Fatal("last element was not removed:", err)
+	}
+	if removed, err := Delete(obj, "tags.5"); err != nil || removed {
+		t.Fatal("expected nothing to be removed beyond the end of the slice:", err)
+	}
+
+	list := []string{"x", "y"}
+	if _, err := Delete(list, "0"); err == nil {
+		t.Fatal("expected an error deleting from a slice passed by value")
+	}
+	if removed, err := Delete(&list, "0"); err != nil || !removed || len(list) != 1 || list[0] != "y" {
+		t.Fatal("element was not removed from a slice pointer:", err)
+	}
+}
+
+func TestDelete_Compiled(t *testing.T) {
+	p := MustCompile("meta.token")
+	obj := map[string]interface{}{"meta": map[string]interface{}{"token": "t"}}
+	if removed, err := p.Delete(obj); err != nil || !removed {
+		t.Fatal("compiled path did not delete:", err)
+	}
+	if p.Exists(obj) {
+		t.Fatal("token still exists")
+	}
+}
@@ -0,0 +1,99 @@
+package dot
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+// Errors returned while following a path are *PathErrors, which wrap a cause that matches one of these with
+// errors.Is, when one applies:
+//
+//	if errors.Is(err, dot.ErrNotFound) {
+//	    // the path leads nowhere in obj
+//	}
+var (
+	// ErrNotFound is matched by errors for missing map keys and struct fields
+	ErrNotFound = errors.New("dot: not found")
+
+	// ErrTypeMismatch is matched by errors for paths that don't fit the values they are applied to, such as a key used
+	// on a number or a slice, or a value that can't be stored at its destination
+	ErrTypeMismatch = errors.New("dot: type mismatch")
+
+	// ErrNotAddressable is matched by errors for values that can't be written to, such as a struct passed to Set by
+	// value or an unexported field
+	ErrNotAddressable = errors.New("dot: not addressable")
+
+	// ErrIndexOutOfRange is matched by *IndexOutOfRangeError
+	ErrIndexOutOfRange = errors.New("dot: index out of range")
+)
+
+// PathError records where following a path failed.  Path is the full path, Segment is the position of the segment that
+// failed within it (counting from 0), and Kind is the kind of the value that segment was applied to, which is
+// reflect.Invalid for nil.  Err is the cause, such as an *IndexOutOfRangeError, and is returned by Unwrap.
+type PathError struct {
+	Path    string
+	Segment int
+	Kind    reflect.Kind
+	Err     error
+}
+
+func (e *PathError) Error() string {
+	return e.Err.Error() + " (segment " + strconv.Itoa(e.Segment) + " of " + e.Path + ", in a " + e.Kind.String() + ")"
+}
+
+// Unwrap returns the cause of the error
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// newPathError wraps err, which happened applying seg to a value of the given kind.  Errors that are already
+// *PathErrors are returned as they are, so the segment deepest in the path is the one reported.
+func newPathError(seg segment, kind reflect.Kind, err error) error {
+	if _, ok := err.(*PathError); ok {
+		return err
+	}
+	return &PathError{Segment: seg.index, Kind: kind, Err: err}
+}
+
+// withPath fills in the full path of err, if it is a *PathError
+func withPath(err error, prop string) error {
+	if pathErr, ok := err.(*PathError); ok && pathErr.Path == "" {
+		pathErr.Path = prop
+	}
+	return err
+}
+
+// kindOf returns the kind of container obj is, looking through any pointers to it as Set does, or reflect.Invalid for
+// nil.  A nil pointer is reported as a pointer.
+func kindOf(obj interface{}) reflect.Kind {
+	val := reflect.ValueOf(obj)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	return val.Kind()
+}
+
+// classifiedError is an error described by reason, which errors.Is matches to class, one of the sentinel errors
+type classifiedError struct {
+	class  error
+	reason string
+}
+
+func (e *classifiedError) Error() string {
+	return e.reason
+}
+
+func (e *classifiedError) Is(target error) bool {
+	return target == e.class
+}
+
+// typeMismatch returns an error described by reason that matches ErrTypeMismatch
+func typeMismatch(reason string) error {
+	return &classifiedError{class: ErrTypeMismatch, reason: reason}
+}
+
+// notAddressable returns an error described by reason that matches ErrNotAddressable
+func notAddressable(reason string) error {
+	return &classifiedError{class: ErrNotAddressable, reason: reason}
+}
@@ -0,0 +1,149 @@
+package dot
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPathError_Get(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type User struct {
+		Address Address
+		Tags    []string
+	}
+
+	u := User{Tags: []string{"a"}}
+
+	_, err := Get(u, "Address.Zip")
+	var pathErr *PathError
+	if !errors.As(err, &pathErr) {
+		t.Fatal("did not get a PathError, got", err)
+	}
+	if pathErr.Path != "Address.Zip" || pathErr.Segment != 1 || pathErr.Kind != reflect.Struct {
+		t.Fatal("PathError did not report where the path failed:", pathErr.Path, pathErr.Segment, pathErr.Kind)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatal("missing field did not match ErrNotFound")
+	}
+
+	_, err = Get(u, "Tags[3]")
+	var rangeErr *IndexOutOfRangeError
+	if !errors.Is(err, ErrIndexOutOfRange) || !errors.As(err, &rangeErr) || rangeErr.Index != 3 {
+		t.Fatal("out of range index did not match ErrIndexOutOfRange:", err)
+	}
+	if errors.As(err, &pathErr); pathErr.Kind != reflect.Slice {
+		t.Fatal("PathError did not report the slice kind")
+	}
+
+	_, err = Get(u, "Tags.0.x")
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatal("key of a string did not match ErrTypeMismatch:", err)
+	}
+	if errors.As(err, &pathErr); pathErr.Segment != 2 || pathErr.Kind != reflect.String {
+		t.Fatal("PathError did not report the failing segment:", pathErr.Segment, pathErr.Kind)
+	}
+
+	// the last error is reported when falling back through several paths
+	_, err = Get(u, "Nope", "Tags.x")
+	if errors.As(err, &pathErr); pathErr.Path != "Tags.x" {
+		t.Fatal("PathError did not report the last path tried:", pathErr.Path)
+	}
+}
+
+func TestPathError_Set(t *testing.T) {
+	type Config struct {
+		Port     int
+		Limits   map[int]int
+		internal string
+	}
+
+	c := Config{}
+
+	err := Set(c, "Port", 1)
+	if !errors.Is(err, ErrNotAddressable) {
+		t.Fatal("struct passed by value did not match ErrNotAddressable:", err)
+	}
+
+	err = Set(&c, "internal", "x")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatal("unexported field did not match ErrNotFound:", err)
+	}
+
+	err = Set(&c, "Port", "80")
+	var pathErr *PathError
+	if !errors.Is(err, ErrTypeMismatch) || !errors.As(err, &pathErr) {
+		t.Fatal("value of the wrong type did not match ErrTypeMismatch:", err)
+	}
+	if pathErr.Path != "Port" || pathErr.Segment != 0 || pathErr.Kind != reflect.Struct {
+		t.Fatal("PathError did not report where Set failed:", pathErr.Path, pathErr.Segment, pathErr.Kind)
+	}
+
+	err = Set(&c, "Limits.x", 1)
+	if errors.As(err, &pathErr); pathErr.Segment != 1 || pathErr.Kind != reflect.Map {
+		t.Fatal("PathError did not report the map:", err)
+	}
+
+	var convErr *ConversionError
+	err = Set(&c, "Port", 1.5, WithConversion())
+	if !errors.Is(err, ErrTypeMismatch) || !errors.As(err, &convErr) {
+		t.Fatal("lossy conversion was not a ConversionError matching ErrTypeMismatch:", err)
+	}
+}
+
+func TestPathError_DeleteAndLookup(t *testing.T) {
+	obj := map[string]interface{}{"n": 1}
+
+	_, err := Delete(obj, "n.x")
+	var pathErr *PathError
+	if !errors.Is(err, ErrTypeMismatch) || !errors.As(err, &pathErr) || pathErr.Path != "n.x" {
+		t.Fatal("Delete into a number did not report a PathError:", err)
+	}
+
+	_, _, err = Lookup(obj, "n.x")
+	if !errors.As(err, &pathErr) || pathErr.Segment != 1 || pathErr.Kind != reflect.Int {
+		t.Fatal("Lookup into a number did not report a PathError:", err)
+	}
+
+	_, err = MustCompile("n[0]").Get(obj)
+	if !errors.As(err, &pathErr) || pathErr.Path != "n[0]" {
+		t.Fatal("compiled path did not report its path:", err)
+	}
+
+	_, err = GetPointer(obj, "/n/x")
+	if !errors.As(err, &pathErr) || pathErr.Path != "/n/x" || pathErr.Segment != 1 {
+		t.Fatal("JSON Pointer did not report its path:", err)
+	}
+}
+
+func TestPathError_KindThroughPointers(t *testing.T) {
+	type Settings struct {
+		Port int
+	}
+	s := Settings{}
+
+	_, getErr := Get(&s, "nope")
+	_, getValueErr := Get(s, "nope")
+	setErr := Set(&s, "nope", 1)
+	_, _, lookupErr := Lookup(&s, "Port.x")
+	_, allErr := GetAll(&s, "nope")
+
+	for name, err := range map[string]error{
+		"Get":          getErr,
+		"Get by value": getValueErr,
+		"Set":          setErr,
+		"GetAll":       allErr,
+	} {
+		var pathErr *PathError
+		if !errors.As(err, &pathErr) || pathErr.Kind != reflect.Struct {
+			t.Error(name, "did not report the struct that was hit:", err)
+		}
+	}
+
+	var pathErr *PathError
+	if !errors.As(lookupErr, &pathErr) || pathErr.Kind != reflect.Int {
+		t.Error("Lookup did not report the int that was hit:", lookupErr)
+	}
+}
@@ -1,13 +1,23 @@
 package dot
 
-import "reflect"
+import (
+	"errors"
+	"reflect"
+)
 
-// Extend copies non-nil, non-default values from right to left
+// Extend copies non-nil, non-default values from right to left.  Struct fields on the left tagged `dot:",readonly"`
+// are skipped.  Values are set as Set sets them, so a Coercer registered with RegisterCoercion converts values whose
+// types differ from the fields they are copied to, and values that coerce to the number 0 count as defaults.
 func Extend(to interface{}, from interface{}) error {
+	return std.Extend(to, from)
+}
+
+// Extend does what the package-level Extend does, using the Accessor's options
+func (a *Accessor) Extend(to interface{}, from interface{}) error {
 
-	keys := KeysRecursiveLeaves(from)
+	keys := a.KeysRecursiveLeaves(from)
 	for _, k := range keys {
-		i, err := Get(from, k)
+		i, err := a.Get(from, k)
 		if err != nil {
 			return err
 		}
@@ -49,7 +59,12 @@ func Extend(to interface{}, from interface{}) error {
 			continue
 		}
 
-		if err := Set(to, k, i); err != nil {
+		// read-only fields are left alone rather than failing the whole extend
+		if err := a.Set(to, k, i); err != nil {
+			var readOnly *ReadOnlyError
+			if errors.As(err, &readOnly) {
+				continue
+			}
 			return err
 		}
 	}
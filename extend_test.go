@@ -159,3 +159,13 @@ func TestExtend_Struct(t *testing.T) {
 		t.Fatal("nil struct overwrote non-nil struct")
 	}
 }
+
+func TestExtend_FractionalText(t *testing.T) {
+	to := map[string]interface{}{"ratio": "1"}
+	if err := Extend(to, map[string]interface{}{"ratio": "0.5"}); err != nil {
+		t.Fatal(err)
+	}
+	if to["ratio"] != "0.5" {
+		t.Error("fractional text was treated as a default value, got", to["ratio"])
+	}
+}
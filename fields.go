@@ -0,0 +1,371 @@
+package dot
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// field is a struct field as paths see it
+type field struct {
+	// name is the key that addresses the field, and the key Keys reports for it
+	name string
+
+	// goName is the field's name in Go
+	goName string
+
+	// aliases are other keys that address the field, from its dot tag
+	aliases []string
+
+	// readOnly fields can be read, but not written by Set or Extend
+	readOnly bool
+
+	// tagged is set when a json or dot tag gave the field its name
+	tagged bool
+
+	// embedded is set for an embedded struct whose fields are promoted.  It can still be addressed by its type name,
+	// as in Go, but Keys doesn't report it.
+	embedded bool
+
+	// index is the field's index sequence, which passes through embedded structs for promoted fields
+	index []int
+}
+
+// fieldCacheKey identifies the fields of a struct type under the options that affect them
+type fieldCacheKey struct {
+	t        reflect.Type
+	jsonTags bool
+}
+
+// fieldCache holds the []field computed for each fieldCacheKey
+var fieldCache sync.Map
+
+// structFields lists the fields of the struct type t that paths can address, in declaration order.  Unexported fields
+// and fields tagged `dot:"-"` are never included.  When json tags are honored, fields tagged `json:"-"` are left out,
+// and fields with a json name are named by it.  A name in the dot tag takes precedence over both.
+//
+// The fields of embedded structs (and pointers to structs) are promoted the way encoding/json promotes them: a field
+// at a shallower depth hides any deeper field of the same name, and when several fields share a name at the same
+// depth, the only one with a tagged name wins, or else none of them do.  Embedded structs given a name by a tag are
+// not promoted.
+func structFields(t reflect.Type, o *options) []field {
+	key := fieldCacheKey{t: t, jsonTags: o.jsonTags}
+	if cached, ok := fieldCache.Load(key); ok {
+		return cached.([]field)
+	}
+
+	type level struct {
+		t     reflect.Type
+		index []int
+	}
+
+	var fields []field
+	decided := make(map[string]bool)
+	visited := make(map[reflect.Type]bool)
+
+	// walk breadth-first, so shallower fields are decided before deeper ones
+	for current := []level{{t: t}}; len(current) > 0; {
+		var next []level
+		var candidates []field
+
+		for _, lv := range current {
+			if visited[lv.t] {
+				continue
+			}
+			visited[lv.t] = true
+
+			for i := 0; i < lv.t.NumField(); i++ {
+				sf := lv.t.Field(i)
+				index := append(append([]int{}, lv.index...), i)
+
+				embeddedType := sf.Type
+				if embeddedType.Kind() == reflect.Ptr {
+					embeddedType = embeddedType.Elem()
+				}
+				promotes := sf.Anonymous && embeddedType.Kind() == reflect.Struct
+
+				// unexported embedded structs may still have exported fields to promote
+				if sf.PkgPath != "" && !promotes {
+					continue
+				}
+
+				f, ok := newField(sf, index, o)
+				if !ok {
+					continue
+				}
+
+				if promotes && !f.tagged {
+					next = append(next, level{t: embeddedType, index: index})
+					if sf.PkgPath != "" {
+						continue
+					}
+					f.embedded = true
+				}
+				candidates = append(candidates, f)
+			}
+		}
+
+		// settle the names found at this depth
+		byName := make(map[string][]field)
+		var names []string
+		for _, f := range candidates {
+			if _, ok := byName[f.name]; !ok {
+				names = append(names, f.name)
+			}
+			byName[f.name] = append(byName[f.name], f)
+		}
+
+		for _, name := range names {
+			if decided[name] {
+				continue
+			}
+			decided[name] = true
+
+			if winner, ok := dominantField(byName[name]); ok {
+				fields = append(fields, winner)
+			}
+		}
+
+		current = next
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return indexLess(fields[i].index, fields[j].index)
+	})
+
+	fieldCache.Store(key, fields)
+	return fields
+}
+
+// newField describes sf, which has the index sequence index, returning false if tags exclude it
+func newField(sf reflect.StructField, index []int, o *options) (field, bool) {
+	f := field{name: sf.Name, goName: sf.Name, index: index}
+	if o.jsonTags {
+		name, ok := jsonName(sf)
+		if !ok {
+			return f, false
+		}
+		if name != "" {
+			f.name = name
+			f.tagged = true
+		}
+	}
+
+	if tag, ok := sf.Tag.Lookup("dot"); ok {
+		if tag == "-" {
+			return f, false
+		}
+		applyDotTag(&f, tag)
+	}
+	return f, true
+}
+
+// dominantField picks the field that wins among fields sharing a name at the same depth
+func dominantField(fields []field) (field, bool) {
+	if len(fields) == 1 {
+		return fields[0], true
+	}
+
+	var tagged []field
+	for _, f := range fields {
+		if f.tagged {
+			tagged = append(tagged, f)
+		}
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+	return field{}, false
+}
+
+// indexLess orders index sequences as the fields they refer to are declared
+func indexLess(a []int, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// fieldValue returns the field of the struct v at index, or false if reaching it means passing through a nil embedded
+// pointer
+func fieldValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// settableFieldValue returns the field of the addressable struct v at index, allocating any nil embedded pointers on
+// the way to it
+func settableFieldValue(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, notAddressable("cannot allocate unexported embedded " + v.Type().String())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// applyDotTag applies a dot struct tag to f.  The tag is a comma-separated list, where the first item is the name the
+// field goes by in paths (or empty to keep its name), followed by any of:
+//
+//   - alias=key, which lets key address the field too, and may be repeated
+//   - readonly, which makes Set and Extend refuse to write the field
+//
+// For example, `dot:"id,alias=uid,alias=userId,readonly"`.
+func applyDotTag(f *field, tag string) {
+	items := strings.Split(tag, ",")
+	if items[0] != "" {
+		f.name = items[0]
+		f.tagged = true
+	}
+
+	for _, item := range items[1:] {
+		item = strings.TrimSpace(item)
+		if item == "readonly" {
+			f.readOnly = true
+		} else if strings.HasPrefix(item, "alias=") {
+			f.aliases = append(f.aliases, strings.TrimPrefix(item, "alias="))
+		}
+	}
+}
+
+// findField returns the field of the struct type t addressed by name.  The field's path name is matched first, then
+// its aliases, then its Go name.  How the Go name is matched depends on the case mode: by default, the first letter
+// of name is upper-cased before comparing.  When matching case-insensitively, the field's names are then compared
+// ignoring case, and an *AmbiguousKeyError is returned if they match more than one field.  When matching strictly,
+// only the path name and aliases are compared, exactly as given.
+func findField(t reflect.Type, name string, o *options) (field, error) {
+	fields := structFields(t, o)
+	for _, f := range fields {
+		if f.name == name {
+			return f, nil
+		}
+	}
+
+	for _, f := range fields {
+		for _, alias := range f.aliases {
+			if alias == name {
+				return f, nil
+			}
+		}
+	}
+
+	switch o.caseMode {
+	case caseDefault:
+		titled := strings.Title(name)
+		for _, f := range fields {
+			if f.goName == titled {
+				return f, nil
+			}
+		}
+	case caseInsensitive:
+		for _, f := range fields {
+			if f.goName == name {
+				return f, nil
+			}
+		}
+
+		var matched []field
+		var matchedNames []string
+		for _, f := range fields {
+			for _, candidate := range append([]string{f.name, f.goName}, f.aliases...) {
+				if strings.EqualFold(candidate, name) {
+					matched = append(matched, f)
+					matchedNames = append(matchedNames, candidate)
+					break
+				}
+			}
+		}
+
+		if len(matched) == 1 {
+			return matched[0], nil
+		}
+		if len(matched) > 1 {
+			sort.Strings(matchedNames)
+			return field{}, &AmbiguousKeyError{Key: name, Matches: matchedNames}
+		}
+	}
+	return field{}, &notFoundError{reason: "No such field: " + name + " in obj"}
+}
+
+// foldKey finds the one of keys that matches key case-insensitively, for use when none matches exactly.  It returns
+// false if none match, and an *AmbiguousKeyError if more than one does.
+func foldKey(key string, keys []string) (string, bool, error) {
+	var matches []string
+	for _, k := range keys {
+		if strings.EqualFold(k, key) {
+			matches = append(matches, k)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", false, nil
+	case 1:
+		return matches[0], true, nil
+	}
+
+	sort.Strings(matches)
+	return "", false, &AmbiguousKeyError{Key: key, Matches: matches}
+}
+
+// foldMapKey finds the key of the map m (which must have string keys) that matches key case-insensitively, for use
+// when none matches exactly
+func foldMapKey(m reflect.Value, key string) (reflect.Value, bool, error) {
+	var keys []string
+	for _, k := range m.MapKeys() {
+		keys = append(keys, k.String())
+	}
+
+	found, ok, err := foldKey(key, keys)
+	if !ok {
+		return reflect.Value{}, false, err
+	}
+	return reflect.ValueOf(found).Convert(m.Type().Key()), true, nil
+}
+
+// AmbiguousKeyError is returned when matching case-insensitively and a key matches more than one map key or struct
+// field, none of them exactly.  Matches holds what it matched, sorted.
+type AmbiguousKeyError struct {
+	Key     string
+	Matches []string
+}
+
+func (e *AmbiguousKeyError) Error() string {
+	return "key " + e.Key + " is ambiguous, as it matches " + strings.Join(e.Matches, ", ")
+}
+
+// jsonName returns the name from sf's json tag, which is empty if the tag doesn't rename the field, and false if the
+// tag excludes the field
+func jsonName(sf reflect.StructField) (string, bool) {
+	tag, ok := sf.Tag.Lookup("json")
+	if !ok {
+		return "", true
+	}
+
+	if tag == "-" {
+		return "", false
+	}
+
+	if comma := strings.IndexByte(tag, ','); comma >= 0 {
+		tag = tag[:comma]
+	}
+	return tag, true
+}
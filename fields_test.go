@@ -0,0 +1,415 @@
+package dot
+
+import (
+	"errors"
+	"testing"
+)
+
+type jsonTaggedAddress struct {
+	PostalCode string `json:"postal_code"`
+	City       string
+}
+
+type jsonTaggedUser struct {
+	UserID   int                `json:"user_id"`
+	Email    string             `json:"email,omitempty"`
+	Password string             `json:"-"`
+	Address  jsonTaggedAddress  `json:"address"`
+	Tags     []string           `json:",omitempty"`
+	Previous *jsonTaggedAddress `json:"previous_address"`
+	internal string
+}
+
+func TestWithJSONTags_Get(t *testing.T) {
+	u := jsonTaggedUser{
+		UserID:   7,
+		Email:    "a@b.c",
+		Password: "hunter2",
+		Address:  jsonTaggedAddress{PostalCode: "02134", City: "Boston"},
+		Tags:     []string{"x"},
+	}
+
+	tagged := New(WithJSONTags())
+
+	if tagged.GetInt64(u, "user_id") != 7 {
+		t.Fatal("user_id did not resolve to UserID")
+	}
+	if tagged.GetString(u, "address.postal_code") != "02134" {
+		t.Fatal("address.postal_code did not resolve")
+	}
+	if tagged.GetString(u, "Tags.0") != "x" {
+		t.Fatal("field without a json name was not addressable by its Go name")
+	}
+
+	// Go names still work
+	if tagged.GetString(u, "Email") != "a@b.c" {
+		t.Fatal("Go name did not resolve with json tags honored")
+	}
+
+	// fields tagged "-" can't be reached
+	if _, err := tagged.Get(u, "Password"); err == nil {
+		t.Fatal("json:\"-\" field was addressable")
+	}
+
+	// without the option, json names don't resolve
+	if _, err := Get(u, "user_id"); err == nil {
+		t.Fatal("json name resolved without WithJSONTags")
+	}
+}
+
+func TestWithJSONTags_SetAndKeys(t *testing.T) {
+	tagged := New(WithJSONTags())
+
+	u := jsonTaggedUser{}
+	if err := tagged.Set(&u, "address.postal_code", "10001"); err != nil {
+		t.Fatal(err)
+	}
+	if u.Address.PostalCode != "10001" {
+		t.Fatal("address.postal_code was not set")
+	}
+	if err := tagged.Set(&u, "Password", "x"); err == nil {
+		t.Fatal("json:\"-\" field was settable")
+	}
+
+	keys := tagged.Keys(u)
+	if len(keys) != 5 || !contains(keys, "user_id") || !contains(keys, "email") || !contains(keys, "address") ||
+		!contains(keys, "Tags") || !contains(keys, "previous_address") {
+		t.Fatal("Keys did not report json names")
+	}
+	if contains(keys, "Password") || contains(keys, "internal") {
+		t.Fatal("Keys reported an excluded field")
+	}
+
+	leaves := tagged.KeysRecursiveLeaves(u)
+	if !contains(leaves, "address.postal_code") || !contains(leaves, "address.City") {
+		t.Fatal("KeysRecursiveLeaves did not report json names")
+	}
+	if !contains(tagged.KeysRecursive(u), "address.postal_code") {
+		t.Fatal("KeysRecursive did not report json names")
+	}
+
+	// Extend works from json names on one side to maps on the other
+	patch := map[string]interface{}{
+		"user_id": 9,
+		"address": map[string]interface{}{"postal_code": "94110"},
+	}
+	if err := tagged.Extend(&u, patch); err != nil {
+		t.Fatal(err)
+	}
+	if u.UserID != 9 || u.Address.PostalCode != "94110" {
+		t.Fatal("Extend did not apply json-named values")
+	}
+
+	out := map[string]interface{}{}
+	if err := tagged.Extend(out, u); err != nil {
+		t.Fatal(err)
+	}
+	if tagged.GetString(out, "address.postal_code") != "94110" || out["Password"] != nil {
+		t.Fatal("Extend from a struct did not produce json names")
+	}
+}
+
+func TestKeys_SkipsUnexportedFields(t *testing.T) {
+	keys := Keys(jsonTaggedUser{})
+	if contains(keys, "internal") {
+		t.Fatal("Keys reported an unexported field")
+	}
+	if !contains(keys, "UserID") || !contains(keys, "Password") {
+		t.Fatal("Keys did not report Go names without WithJSONTags")
+	}
+}
+
+type dotTaggedRecord struct {
+	ID        int                    `dot:",readonly"`
+	CreatedAt string                 `json:"created_at" dot:"created,readonly"`
+	Name      string                 `dot:"display_name,alias=name,alias=title"`
+	Secret    string                 `dot:"-"`
+	Meta      map[string]interface{} `dot:"meta,readonly"`
+}
+
+func TestDotTag_NamesAndAliases(t *testing.T) {
+	r := dotTaggedRecord{ID: 1, CreatedAt: "2020-01-01", Name: "first", Secret: "s"}
+
+	for _, prop := range []string{"display_name", "name", "title", "Name"} {
+		if GetString(r, prop) != "first" {
+			t.Error(prop + " did not address Name")
+		}
+	}
+
+	// the dot tag's name wins over the json tag's
+	if GetString(r, "created") != "2020-01-01" || New(WithJSONTags()).GetString(r, "created") != "2020-01-01" {
+		t.Fatal("created did not address CreatedAt")
+	}
+
+	if _, err := Get(r, "Secret"); err == nil {
+		t.Fatal("dot:\"-\" field was addressable")
+	}
+
+	keys := Keys(r)
+	if len(keys) != 4 || !contains(keys, "ID") || !contains(keys, "created") || !contains(keys, "display_name") ||
+		!contains(keys, "meta") {
+		t.Fatal("Keys did not report dot tag names")
+	}
+}
+
+func TestDotTag_ReadOnly(t *testing.T) {
+	r := dotTaggedRecord{ID: 1, CreatedAt: "2020-01-01", Meta: map[string]interface{}{}}
+
+	err := Set(&r, "ID", 2)
+	if err == nil {
+		t.Fatal("read-only field was set")
+	}
+	var roErr *ReadOnlyError
+	if !errors.As(err, &roErr) || roErr.Field != "ID" {
+		t.Fatal("did not get a ReadOnlyError naming the field")
+	}
+
+	// nothing can be written through a read-only field either
+	if err := Set(&r, "meta.x", 1); err == nil {
+		t.Fatal("wrote through a read-only field")
+	}
+
+	if err := Set(&r, "name", "renamed"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Extend applies what it can and leaves read-only fields alone
+	patch := map[string]interface{}{
+		"ID":      99,
+		"created": "1970-01-01",
+		"title":   "patched",
+	}
+	if err := Extend(&r, patch); err != nil {
+		t.Fatal(err)
+	}
+	if r.ID != 1 || r.CreatedAt != "2020-01-01" {
+		t.Fatal("Extend wrote a read-only field")
+	}
+	if r.Name != "patched" {
+		t.Fatal("Extend did not write a writable field")
+	}
+}
+
+func TestWithCaseInsensitive(t *testing.T) {
+	type Profile struct {
+		UserName string
+		Nick     string `dot:"nickname"`
+	}
+
+	folding := New(WithCaseInsensitive())
+
+	p := Profile{UserName: "ann", Nick: "a"}
+	if folding.GetString(p, "userName") != "ann" || folding.GetString(p, "USERNAME") != "ann" {
+		t.Fatal("struct field did not match ignoring case")
+	}
+	if folding.GetString(p, "NickName") != "a" {
+		t.Fatal("dot tag name did not match ignoring case")
+	}
+
+	// by default, only the first letter is forgiven
+	if GetString(p, "userName") != "ann" {
+		t.Fatal("default mode did not upper-case the first letter")
+	}
+	if _, err := Get(p, "username"); err == nil {
+		t.Fatal("default mode matched ignoring case")
+	}
+
+	data := map[string]interface{}{
+		"Email": "ann@example.com",
+		"settings": map[string]string{
+			"Theme": "dark",
+		},
+	}
+	if folding.GetString(data, "email") != "ann@example.com" {
+		t.Fatal("map key did not match ignoring case")
+	}
+	if folding.GetString(data, "SETTINGS.theme") != "dark" {
+		t.Fatal("typed map key did not match ignoring case")
+	}
+	if v, err := Get(data, "email"); v != nil || err != nil {
+		t.Fatal("default mode matched a map key ignoring case")
+	}
+
+	// Set writes to the existing key rather than adding one
+	if err := folding.Set(data, "EMAIL", "new@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 || data["Email"] != "new@example.com" {
+		t.Fatal("Set did not reuse the existing key")
+	}
+	if err := folding.Set(&p, "username", "bob"); err != nil || p.UserName != "bob" {
+		t.Fatal("Set did not match a struct field ignoring case")
+	}
+}
+
+func TestWithCaseInsensitive_Ambiguous(t *testing.T) {
+	folding := New(WithCaseInsensitive())
+
+	data := map[string]interface{}{
+		"id": 1,
+		"ID": 2,
+		"Id": 3,
+	}
+
+	// exact matches always win
+	if folding.GetInt64(data, "ID") != 2 {
+		t.Fatal("exact match did not win")
+	}
+
+	_, err := folding.Get(data, "iD")
+	var ambiguous *AmbiguousKeyError
+	if !errors.As(err, &ambiguous) {
+		t.Fatal("did not get an AmbiguousKeyError")
+	}
+	if len(ambiguous.Matches) != 3 || ambiguous.Matches[0] != "ID" || ambiguous.Matches[2] != "id" {
+		t.Fatal("AmbiguousKeyError did not list the sorted matches")
+	}
+
+	if err := folding.Set(data, "iD", 4); err == nil {
+		t.Fatal("Set did not fail on an ambiguous key")
+	}
+
+	type Clash struct {
+		URL string
+		Url string `dot:"link"`
+	}
+	if _, err := folding.Get(Clash{}, "url"); err == nil {
+		t.Fatal("did not get an error for an ambiguous field")
+	}
+}
+
+func TestWithStrictCase(t *testing.T) {
+	type Profile struct {
+		UserName string
+		Nick     string `dot:"nickname,alias=handle"`
+	}
+
+	strict := New(WithStrictCase())
+	p := Profile{UserName: "ann", Nick: "a"}
+
+	if strict.GetString(p, "UserName") != "ann" || strict.GetString(p, "handle") != "a" {
+		t.Fatal("strict mode did not match exact names")
+	}
+	if _, err := strict.Get(p, "userName"); err == nil {
+		t.Fatal("strict mode upper-cased the first letter")
+	}
+	if _, err := strict.Get(p, "Nick"); err == nil {
+		t.Fatal("strict mode matched the Go name of a renamed field")
+	}
+}
+
+type embeddedBase struct {
+	ID   int
+	Name string
+}
+
+type embeddedAudit struct {
+	CreatedBy string
+	Name      string
+}
+
+type embeddingRecord struct {
+	embeddedBase
+	*embeddedAudit
+	Name  string
+	Title string
+}
+
+type EmbeddedTimestamps struct {
+	Created string
+}
+
+type exportedEmbeddingRecord struct {
+	*EmbeddedTimestamps
+	Value int
+}
+
+func TestEmbedded_Get(t *testing.T) {
+	r := embeddingRecord{embeddedBase: embeddedBase{ID: 7, Name: "base"}, Name: "outer"}
+
+	if v, err := Get(r, "ID"); err != nil || v != 7 {
+		t.Fatal("promoted field was not found:", v, err)
+	}
+
+	// the shallower field hides the embedded ones
+	if v, err := Get(r, "Name"); err != nil || v != "outer" {
+		t.Fatal("shallower field did not win:", v, err)
+	}
+
+	// through a nil embedded pointer, the field is missing
+	if v, err := Get(r, "CreatedBy"); err != nil || v != nil {
+		t.Fatal("field of a nil embedded pointer was not nil:", v, err)
+	}
+
+	r.embeddedAudit = &embeddedAudit{CreatedBy: "me"}
+	if v, err := Get(&r, "CreatedBy"); err != nil || v != "me" {
+		t.Fatal("field of an embedded pointer was not found:", v, err)
+	}
+}
+
+func TestEmbedded_Set(t *testing.T) {
+	r := exportedEmbeddingRecord{}
+	if err := Set(&r, "Created", "today"); err != nil {
+		t.Fatal(err)
+	}
+	if r.EmbeddedTimestamps == nil || r.Created != "today" {
+		t.Fatal("embedded pointer was not allocated for Set")
+	}
+
+	// the embedded struct can still be addressed by its type name
+	if err := Set(&r, "EmbeddedTimestamps.Created", "tomorrow"); err != nil || r.Created != "tomorrow" {
+		t.Fatal("embedded struct was not addressable by name:", err)
+	}
+
+	e := embeddingRecord{}
+	if err := Set(&e, "ID", 3); err != nil || e.ID != 3 {
+		t.Fatal("promoted field was not set:", err)
+	}
+
+	// an unexported embedded pointer can't be allocated
+	if err := Set(&e, "CreatedBy", "me"); err == nil {
+		t.Fatal("expected an error allocating an unexported embedded pointer")
+	}
+}
+
+func TestEmbedded_Keys(t *testing.T) {
+	keys := Keys(embeddingRecord{})
+	if len(keys) != 4 {
+		t.Fatal("expected 4 keys, got", keys)
+	}
+	for _, k := range []string{"ID", "CreatedBy", "Name", "Title"} {
+		if !contains(keys, k) {
+			t.Fatal("Keys did not report", k)
+		}
+	}
+
+	leaves := KeysRecursiveLeaves(exportedEmbeddingRecord{EmbeddedTimestamps: &EmbeddedTimestamps{}})
+	if len(leaves) != 2 || !contains(leaves, "Created") || !contains(leaves, "Value") {
+		t.Fatal("unexpected leaves", leaves)
+	}
+}
+
+func TestEmbedded_Conflicts(t *testing.T) {
+	type left struct{ Shared string }
+	type right struct {
+		Shared string `json:"Shared"`
+	}
+	type untagged struct{ Shared string }
+
+	type tagWins struct {
+		left
+		right
+	}
+	v := tagWins{left{"l"}, right{"r"}}
+	if got, err := New(WithJSONTags()).Get(v, "Shared"); err != nil || got != "r" {
+		t.Fatal("tagged field did not win the conflict:", got, err)
+	}
+
+	type neitherWins struct {
+		left
+		untagged
+	}
+	if _, err := Get(neitherWins{}, "Shared"); err == nil {
+		t.Fatal("expected conflicting fields to hide each other")
+	}
+}
@@ -0,0 +1,380 @@
+package dot
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// filter is a predicate written as [?expression] in a path.  It is evaluated against each child of a node, and only
+// the children it matches are followed.
+type filter interface {
+	match(elem interface{}, o *options) bool
+}
+
+// orFilter matches when either side matches
+type orFilter struct {
+	left, right filter
+}
+
+func (f orFilter) match(elem interface{}, o *options) bool {
+	return f.left.match(elem, o) || f.right.match(elem, o)
+}
+
+// andFilter matches when both sides match
+type andFilter struct {
+	left, right filter
+}
+
+func (f andFilter) match(elem interface{}, o *options) bool {
+	return f.left.match(elem, o) && f.right.match(elem, o)
+}
+
+// comparisonFilter compares two operands with one of ==, !=, <, >, <= or >=
+type comparisonFilter struct {
+	left, right operand
+	op          string
+}
+
+func (f comparisonFilter) match(elem interface{}, o *options) bool {
+	return compare(f.left.value(elem, o), f.right.value(elem, o), f.op)
+}
+
+// existsFilter matches when its operand is present, non-nil and not false, e.g. [?active]
+type existsFilter struct {
+	operand operand
+}
+
+func (f existsFilter) match(elem interface{}, o *options) bool {
+	v := f.operand.value(elem, o)
+	asBool, ok := v.(bool)
+	return v != nil && (!ok || asBool)
+}
+
+// operand is either a literal or a path relative to the element being filtered ("@" is the element itself)
+type operand struct {
+	segments []segment
+	isPath   bool
+	literal  interface{}
+}
+
+func (op operand) value(elem interface{}, o *options) interface{} {
+	if !op.isPath {
+		return op.literal
+	}
+
+	v, _ := getPath(elem, op.segments, o)
+	return v
+}
+
+// compare applies op to left and right.  When both can be coerced to float64 they are compared as numbers, otherwise
+// they are compared as strings.  nil is only equal to nil, and is never ordered relative to anything.
+func compare(left interface{}, right interface{}, op string) bool {
+	if left == nil || right == nil {
+		switch op {
+		case "==":
+			return left == nil && right == nil
+		case "!=":
+			return left != nil || right != nil
+		}
+		return false
+	}
+
+	var cmp int
+	leftFloat, leftOk := CoerceFloat64(left)
+	rightFloat, rightOk := CoerceFloat64(right)
+	if leftOk && rightOk {
+		if leftFloat < rightFloat {
+			cmp = -1
+		} else if leftFloat > rightFloat {
+			cmp = 1
+		}
+	} else {
+		leftString, leftOk := filterString(left)
+		rightString, rightOk := filterString(right)
+		if !leftOk || !rightOk {
+			return op == "!="
+		}
+		cmp = strings.Compare(leftString, rightString)
+	}
+
+	switch op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case ">":
+		return cmp > 0
+	case "<=":
+		return cmp <= 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// filterString is CoerceString, except that the empty string is a valid result
+func filterString(v interface{}) (string, bool) {
+	if asString, ok := v.(string); ok {
+		return asString, true
+	}
+	return CoerceString(v)
+}
+
+type filterTokenKind int
+
+const (
+	tokenOperand filterTokenKind = iota
+	tokenComparison
+	tokenAnd
+	tokenOr
+	tokenOpen
+	tokenClose
+)
+
+type filterToken struct {
+	kind    filterTokenKind
+	text    string
+	operand operand
+}
+
+// lexFilter breaks a filter expression (without its surrounding "[?" and "]") into tokens, where paths are separated
+// by sep
+func lexFilter(expr string, sep string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		two := ""
+		if i+1 < len(expr) {
+			two = expr[i : i+2]
+		}
+
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			tokens = append(tokens, filterToken{kind: tokenOpen, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, filterToken{kind: tokenClose, text: ")"})
+			i++
+		case two == "&&":
+			tokens = append(tokens, filterToken{kind: tokenAnd, text: two})
+			i += 2
+		case two == "||":
+			tokens = append(tokens, filterToken{kind: tokenOr, text: two})
+			i += 2
+		case two == "==" || two == "!=" || two == "<=" || two == ">=":
+			tokens = append(tokens, filterToken{kind: tokenComparison, text: two})
+			i += 2
+		case c == '<' || c == '>':
+			tokens = append(tokens, filterToken{kind: tokenComparison, text: string(c)})
+			i++
+		case c == '\'' || c == '"':
+			end := i + 1
+			var sb strings.Builder
+			for ; end < len(expr) && expr[end] != c; end++ {
+				if expr[end] == '\\' && end+1 < len(expr) {
+					end++
+				}
+				sb.WriteByte(expr[end])
+			}
+			if end >= len(expr) {
+				return nil, errors.New("unterminated string in filter " + expr)
+			}
+			tokens = append(tokens, filterToken{kind: tokenOperand, text: expr[i : end+1], operand: operand{literal: sb.String()}})
+			i = end + 1
+		default:
+			text, end, err := lexOperand(expr, i, sep)
+			if err != nil {
+				return nil, err
+			}
+			if end == i {
+				return nil, errors.New("unexpected " + string(c) + " in filter " + expr)
+			}
+			o, err := parseOperand(text, sep)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, filterToken{kind: tokenOperand, text: text, operand: o})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// lexOperand reads the unquoted operand starting at start, returning it along with the position just past it.  Paths
+// may contain anything parsePath reads, such as escaped characters and bracketed segments holding quotes or other
+// brackets, and a quoted key may follow a separator, as in @.'a.b', which is read as @['a.b'].
+func lexOperand(expr string, start int, sep string) (string, int, error) {
+	var sb strings.Builder
+	i := start
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case c == '\\' && i+1 < len(expr):
+			sb.WriteString(expr[i : i+2])
+			i += 2
+		case c == '[':
+			end := closingBracket(expr, i)
+			if end < 0 {
+				return "", 0, errors.New("missing ] in filter " + expr)
+			}
+			sb.WriteString(expr[i : end+1])
+			i = end + 1
+		case (c == '\'' || c == '"') && i > start && strings.HasSuffix(sb.String(), sep):
+			_, end, err := parseQuoted(expr, i)
+			if err != nil {
+				return "", 0, err
+			}
+			text := strings.TrimSuffix(sb.String(), sep)
+			sb.Reset()
+			sb.WriteString(text + "[" + expr[i:end+1] + "]")
+			i = end + 1
+		case strings.ContainsRune(" \t()=!<>&|'\"", rune(c)):
+			return sb.String(), i, nil
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return sb.String(), i, nil
+}
+
+// parseOperand interprets an unquoted operand as a number, true, false, null, or otherwise a path
+func parseOperand(text string, sep string) (operand, error) {
+	switch text {
+	case "true":
+		return operand{literal: true}, nil
+	case "false":
+		return operand{literal: false}, nil
+	case "null":
+		return operand{}, nil
+	case "@":
+		return operand{isPath: true}, nil
+	}
+
+	if f, err := strconv.ParseFloat(text, 64); err == nil {
+		return operand{literal: f}, nil
+	}
+
+	// the element itself may begin a path, as in @.name or @['name']
+	if strings.HasPrefix(text, "@"+sep) {
+		text = text[1+len(sep):]
+	} else if strings.HasPrefix(text, "@[") {
+		text = text[1:]
+	}
+
+	segments, err := parsePath(text, sep)
+	if err != nil {
+		return operand{}, err
+	}
+	return operand{segments: segments, isPath: true}, nil
+}
+
+// parseFilter parses a filter expression (without its surrounding "[?" and "]"), where paths are separated by sep
+func parseFilter(expr string, sep string) (filter, error) {
+	tokens, err := lexFilter(expr, sep)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens, expr: expr}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, errors.New("unexpected " + p.tokens[p.pos].text + " in filter " + expr)
+	}
+	return f, nil
+}
+
+// filterParser is a recursive descent parser over filter tokens, where || binds more loosely than &&
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+	expr   string
+}
+
+func (p *filterParser) peek() *filterToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *filterParser) parseOr() (filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for t := p.peek(); t != nil && t.kind == tokenOr; t = p.peek() {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filter, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for t := p.peek(); t != nil && t.kind == tokenAnd; t = p.peek() {
+		p.pos++
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = andFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseTerm() (filter, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, errors.New("unexpected end of filter " + p.expr)
+	}
+	p.pos++
+
+	if t.kind == tokenOpen {
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing == nil || closing.kind != tokenClose {
+			return nil, errors.New("missing ) in filter " + p.expr)
+		}
+		p.pos++
+		return f, nil
+	}
+
+	if t.kind != tokenOperand {
+		return nil, errors.New("unexpected " + t.text + " in filter " + p.expr)
+	}
+	left := t.operand
+
+	op := p.peek()
+	if op == nil || op.kind != tokenComparison {
+		return existsFilter{operand: left}, nil
+	}
+	p.pos++
+
+	right := p.peek()
+	if right == nil || right.kind != tokenOperand {
+		return nil, errors.New("missing right side of " + op.text + " in filter " + p.expr)
+	}
+	p.pos++
+
+	return comparisonFilter{left: left, right: right.operand, op: op.text}, nil
+}
@@ -0,0 +1,141 @@
+package dot
+
+import "testing"
+
+func TestGetAll_Filter(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "pen", "price": 2, "qty": 10},
+			map[string]interface{}{"name": "lamp", "price": 24.5, "qty": 0},
+			map[string]interface{}{"name": "desk", "price": "180", "qty": int64(3)},
+		},
+	}
+
+	matches, err := GetAll(data, "items[?price>10].name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 || matches[0].Value != "lamp" || matches[1].Value != "desk" {
+		t.Fatal("price>10 did not match lamp and desk")
+	}
+	if matches[1].Path != "items.2.name" {
+		t.Fatal("filter match path was not items.2.name")
+	}
+
+	matches, err = GetAll(data, "items[?price > 10 && qty >= 1].name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Value != "desk" {
+		t.Fatal("&& did not narrow the matches to desk")
+	}
+
+	matches, err = GetAll(data, "items[?name == 'pen' || (qty == 0 && price < 100)].name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 || matches[0].Value != "pen" || matches[1].Value != "lamp" {
+		t.Fatal("|| did not match pen and lamp")
+	}
+
+	matches, err = GetAll(data, "items[?name != \"pen\"]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Fatal("!= did not exclude pen")
+	}
+}
+
+func TestGetAll_FilterMapsAndNesting(t *testing.T) {
+	type User struct {
+		Name   string
+		Active bool
+		Meta   map[string]interface{}
+	}
+
+	users := map[string]User{
+		"u1": {Name: "ann", Active: true, Meta: map[string]interface{}{"age": 40}},
+		"u2": {Name: "bob", Meta: map[string]interface{}{"age": 17}},
+		"u3": {Name: "cy", Active: true},
+	}
+
+	// filters apply to the values of maps too, and a bare operand tests for truthiness
+	matches, err := GetAll(users, "[?Active].Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 || matches[0].Path != "u1.Name" || matches[1].Path != "u3.Name" {
+		t.Fatal("[?Active] did not match the active users")
+	}
+
+	// operands may be paths, and missing values only equal null
+	matches, err = GetAll(users, "[?Meta.age >= 18].Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Value != "ann" {
+		t.Fatal("nested operand path did not match ann")
+	}
+
+	matches, err = GetAll(users, "[?Meta.age == null].Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Value != "cy" {
+		t.Fatal("== null did not match cy")
+	}
+
+	// "@" is the element being filtered
+	matches, err = GetAll([]int{3, 8, 12}, "[?@ > 5]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 || matches[0].Value != 8 {
+		t.Fatal("@ > 5 did not match 8 and 12")
+	}
+}
+
+func TestGetAll_FilterSyntaxErrors(t *testing.T) {
+	for _, prop := range []string{
+		"items[?price>10",
+		"items[?price>]",
+		"items[?(price>1]",
+		"items[?price ! 2]",
+		"items[?name == 'x]",
+		"items[?a>1]name",
+		"items[?tags[0=='x']",
+		"items[?@.'a.b==1]",
+	} {
+		if _, err := GetAll(map[string]interface{}{}, prop); err == nil {
+			t.Error("did not get a syntax error for " + prop)
+		}
+	}
+}
+
+func TestGetAll_FilterOperandPaths(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "pen", "tags": []interface{}{"x", "y"}, "a.b": 1, "[odd]": true},
+			map[string]interface{}{"name": "lamp", "tags": []interface{}{"y"}, "a.b": 2},
+		},
+	}
+
+	for prop, want := range map[string]string{
+		"items[?tags[0]=='x'].name":                          "pen",
+		"items[?@.tags[-1] == 'y' && @.tags[0] != 'x'].name": "lamp",
+		"items[?@.'a.b'==1].name":                            "pen",
+		"items[?@[\"a.b\"] == 2].name":                       "lamp",
+		"items[?a\\.b==2].name":                              "lamp",
+		"items[?@['[odd]']].name":                            "pen",
+	} {
+		matches, err := GetAll(data, prop)
+		if err != nil {
+			t.Error(prop, err)
+			continue
+		}
+		if len(matches) != 1 || matches[0].Value != want {
+			t.Error(prop, "did not match only", want, "got", matches)
+		}
+	}
+}
@@ -1,8 +1,8 @@
 package dot
 
 import (
-	"errors"
-	"github.com/oleiade/reflections"
+	"encoding/json"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -12,47 +12,101 @@ import (
 // The candidates are processed in the order given, and the first non-nil result is returned.
 // If a property
 func Get(obj interface{}, props ...string) (interface{}, error) {
+	return std.Get(obj, props...)
+}
+
+// Get does what the package-level Get does, using the Accessor's options
+func (a *Accessor) Get(obj interface{}, props ...string) (interface{}, error) {
+	v, _, err := getFirst(a, obj, props, func(v interface{}) (interface{}, error) {
+		return v, nil
+	})
+	return v, err
+}
+
+// getFirst follows each of props from obj in turn, returning the first non-nil value that coerce accepts, and whether
+// there was one.  Otherwise, it returns the most recent error, if any: a path that couldn't be followed, or a value
+// that coerce rejected.  This is the fallback logic shared by Get and the typed getters.
+func getFirst[T any](a *Accessor, obj interface{}, props []string, coerce func(interface{}) (T, error)) (T, bool, error) {
+	var zero T
 	if obj == nil {
-		return nil, nil
+		return zero, false, nil
 	}
 
 	// allow fallback to other properties if props earlier in the list
 	// have errors (probably because they don't exist)
 	var lastError error
-	var err error
 
 	// loop through each property option
 	for _, prop := range props {
+		segments, err := a.parse(prop)
+		if err != nil {
+			lastError = err
+			continue
+		}
 
-		// initialize a cursor for the current descendent of obj
-		objCursor := obj
+		// follow the path from obj - if we can't, mark it as the most recent error and move to the next property option
+		objCursor, err := getPath(obj, segments, a.options)
+		if err != nil {
+			lastError = withPath(err, prop)
+			continue
+		}
 
-		// TODO: improve, feels hacky - we replace escaped . to "beep", then replace again before mapping
-		// we want to allow users to use backslash to escape periods in case the prop
-		// names have periods - we need to make some method of letting users do this
-		rep := strings.ReplaceAll(prop, "\\.", "\a")
+		// if we ended up picking a non-nil leaf that can be coerced, return it (don't process more options)
+		// note that lastError is no longer applicable, as we found a valid fallback
+		if objCursor != nil {
+			v, err := coerce(objCursor)
+			if err != nil {
+				lastError = err
+				continue
+			}
+			return v, true, nil
+		}
+	}
 
-		// continue to follow the dot-path, using the cursor
-		for _, key := range strings.Split(rep, ".") {
+	return zero, false, lastError
+}
 
-			// get the value one level down from the objCursor
-			//
-			if objCursor, err = getProperty(objCursor, strings.ReplaceAll(key, "\a", ".")); err != nil {
+// coerceWith adapts one of the Coerce functions for getFirst
+func coerceWith[T any](f func(interface{}) (T, bool)) func(interface{}) (T, error) {
+	return func(v interface{}) (T, error) {
+		coerced, ok := f(v)
+		if !ok {
+			return coerced, typeMismatch("value of type " + reflect.TypeOf(v).String() + " could not be coerced")
+		}
+		return coerced, nil
+	}
+}
 
-				// if we can't follow the path, mark it as the most recent error and move to the next property option
-				lastError = err
-				break
-			}
+// getPath follows each of the segments from obj, returning the value at the end
+func getPath(obj interface{}, segments []segment, o *options) (interface{}, error) {
+	for _, seg := range segments {
+		if err := checkSingleSegment(obj, seg); err != nil {
+			return nil, newPathError(seg, kindOf(obj), err)
 		}
 
-		// if we ended up picking a non-nil leaf, return it (don't process more options)
-		// note that lastError is no longer applicable, as we found a valid fallback
-		if objCursor != nil {
-			return objCursor, nil
+		// get the value one level down from the obj
+		next, err := getProperty(obj, seg.key, o)
+		if err != nil {
+			return nil, newPathError(seg, kindOf(obj), err)
 		}
+		obj = next
+	}
+	return obj, nil
+}
+
+// checkSingleSegment returns an error if seg can't be followed from obj by an operation that reads a single value
+func checkSingleSegment(obj interface{}, seg segment) error {
+	if seg.fansOut() || seg.descend {
+		return typeMismatch("wildcards, filters and recursive descent may only be used with GetAll")
+	}
+	if seg.kind == appendSegment {
+		return errAppendOnlyInSet
 	}
 
-	return nil, lastError
+	if seg.pointer {
+		return checkPointerIndexOf(obj, seg)
+	}
+	return nil
 }
 
 // GetString does what Get does, except it continues through props until
@@ -62,30 +116,13 @@ func Get(obj interface{}, props ...string) (interface{}, error) {
 // It can't be implemented by attempting to coerce Get once complete,
 // because of the fallback logic for when more than one prop is passed.
 func GetString(obj interface{}, props ...string) string {
-	if obj == nil {
-		return ""
-	}
-
-	var err error
-
-	for _, prop := range props {
-		objCursor := obj
-
-		for _, key := range strings.Split(prop, ".") {
-			objCursor, err = getProperty(objCursor, key)
-			if err != nil {
-				break
-			}
-		}
+	return std.GetString(obj, props...)
+}
 
-		if objCursor != nil {
-			asString, ok := CoerceString(objCursor)
-			if ok {
-				return asString
-			}
-		}
-	}
-	return ""
+// GetString does what the package-level GetString does, using the Accessor's options
+func (a *Accessor) GetString(obj interface{}, props ...string) string {
+	v, _, _ := getFirst(a, obj, props, coerceWith(CoerceString))
+	return v
 }
 
 // GetInt64 does what Get does, except it continues through props until
@@ -95,80 +132,53 @@ func GetString(obj interface{}, props ...string) string {
 // It can't be implemented by attempting to coerce Get once complete,
 // because of the fallback logic for when more than one prop is passed.
 func GetInt64(obj interface{}, props ...string) int64 {
-	if obj == nil {
-		return 0
-	}
-
-	for _, prop := range props {
-
-		// Get the array access
-		arr := strings.Split(prop, ".")
+	return std.GetInt64(obj, props...)
+}
 
-		objCursor := obj
+// GetInt64 does what the package-level GetInt64 does, using the Accessor's options
+func (a *Accessor) GetInt64(obj interface{}, props ...string) int64 {
+	v, _, _ := getFirst(a, obj, props, coerceWith(CoerceInt64))
+	return v
+}
 
-		var err error
-		for _, key := range arr {
-			objCursor, err = getProperty(objCursor, key)
-			if err != nil {
-				break
-			}
-		}
+// GetBool does what Get does, except it continues through props until
+// it not only gets a non-nil value, but also gets something that can be
+// cast/coerced to a bool value.  Will return false if the property doesn't
+// exist or could not be coerced.
+func GetBool(obj interface{}, props ...string) bool {
+	return std.GetBool(obj, props...)
+}
 
-		if objCursor != nil {
-			as64, ok := CoerceInt64(objCursor)
-			if ok {
-				return as64
-			}
-		}
-	}
-	return 0
+// GetBool does what the package-level GetBool does, using the Accessor's options
+func (a *Accessor) GetBool(obj interface{}, props ...string) bool {
+	v, _, _ := getFirst(a, obj, props, coerceWith(CoerceBool))
+	return v
 }
 
-// CoerceInt64 will make a best-effort to convert the provided argument to an int64.  It supports int64, int32, int,
-// float64, float32 as acceptable inputs, but expect this list to expand further with new releases
+// CoerceInt64 will make a best-effort to convert the provided argument to an int64.  It supports every integer and
+// float type, pointers to them, json.Number, big.Int, and strings or []byte holding decimal integers.  Floats are
+// truncated, while values outside the range of an int64 can't be coerced; see CoerceInt64Strict to reject fractions
+// too.
 func CoerceInt64(obj interface{}) (int64, bool) {
-	as64, ok := obj.(int64)
-	if ok {
-		return as64, true
-	}
-
-	as32, ok := obj.(int32)
-	if ok {
-		return int64(as32), true
+	if i, registered, ok := registeredAs[int64](obj); registered {
+		return i, ok
 	}
 
-	asInt, ok := obj.(int)
-	if ok {
-		return int64(asInt), true
-	}
-
-	fromFloat64, ok := obj.(float64)
-	if ok {
-		return int64(fromFloat64), true
+	n, ok := numberOf(obj)
+	if !ok {
+		return 0, false
 	}
 
-	fromFloat32, ok := obj.(float32)
-	if ok {
-		return int64(fromFloat32), true
-	}
-
-	asString, ok := obj.(string)
-	if ok {
-		intVal, err := strconv.Atoi(asString)
-		if err == nil {
-			return int64(intVal), true
+	if n.kind == floatNumber {
+		// strings must hold integers
+		if n.text || math.IsNaN(n.f) || n.f < math.MinInt64 || n.f >= math.MaxInt64 {
+			return 0, false
 		}
+		return int64(n.f), true
 	}
 
-	asBytes, ok := obj.([]byte)
-	if ok {
-		intVal, err := strconv.Atoi(string(asBytes))
-		if err == nil {
-			return int64(intVal), true
-		}
-	}
-
-	return 0, false
+	i, reason := n.int64()
+	return i, reason == ""
 }
 
 // GetFloat64 does what Get does, except it continues through props until
@@ -178,153 +188,242 @@ func CoerceInt64(obj interface{}) (int64, bool) {
 // It can't be implemented by attempting to coerce Get once complete,
 // because of the fallback logic for when more than one prop is passed.
 func GetFloat64(obj interface{}, props ...string) float64 {
-	if obj == nil {
-		return 0
+	return std.GetFloat64(obj, props...)
+}
+
+// GetFloat64 does what the package-level GetFloat64 does, using the Accessor's options
+func (a *Accessor) GetFloat64(obj interface{}, props ...string) float64 {
+	v, _, _ := getFirst(a, obj, props, coerceWith(CoerceFloat64))
+	return v
+}
+
+// CoerceFloat64 will make a best-effort to convert the provided argument to an float64.  It supports every integer and
+// float type, pointers to them, json.Number, big.Int, and strings or []byte holding numbers.  Large integers may lose
+// precision.
+func CoerceFloat64(obj interface{}) (float64, bool) {
+	if f, registered, ok := registeredAs[float64](obj); registered {
+		return f, ok
 	}
 
-	for _, prop := range props {
+	n, ok := numberOf(obj)
+	if !ok {
+		return 0, false
+	}
+	return n.float64(), true
+}
 
-		// Get the array access
-		arr := strings.Split(prop, ".")
+// CoerceString will make a best-effort to convert the provided argument to a string.  It supports string as well as
+// anything supported by CoerceFloat64 and CoerceInt64, rendering integers exactly, bools, named string types, and
+// types implementing encoding.TextMarshaler, fmt.Stringer, driver.Valuer or json.Marshaler (see RegisterCoercion).
+func CoerceString(objCursor interface{}) (string, bool) {
+	if s, registered, ok := registeredAs[string](objCursor); registered {
+		return s, ok
+	}
 
-		objCursor := obj
+	asString, ok := objCursor.(string)
+	if ok && asString != "" {
+		return asString, true
+	}
 
-		var err error
-		for _, key := range arr {
-			objCursor, err = getProperty(objCursor, key)
-			if err != nil {
-				break
-			}
-		}
+	asStringPtr, ok := objCursor.(*string)
+	if ok && asStringPtr != nil {
+		return *asStringPtr, true
+	}
 
-		if objCursor != nil {
-			as64, ok := CoerceFloat64(objCursor)
-			if ok {
-				return as64
-			}
-		}
+	asJSONNumber, ok := objCursor.(json.Number)
+	if ok && asJSONNumber != "" {
+		return string(asJSONNumber), true
 	}
-	return 0
-}
 
-// CoerceFloat64 will make a best-effort to convert the provided argument to an float64.  It supports int64, int32, int,
-// float64, float32 as acceptable inputs, but expect this list to expand further with new releases
-func CoerceFloat64(obj interface{}) (float64, bool) {
-	as64, ok := obj.(float64)
-	if ok {
-		return as64, true
+	// a type's own rendering is preferred over its number, so enums with a String method give their names
+	var marshaled string
+	if coerceMarshaled(objCursor, func(v interface{}) bool {
+		marshaled, ok = CoerceString(v)
+		return ok
+	}) {
+		return marshaled, true
 	}
 
-	as32, ok := obj.(float32)
+	asNumber, ok := scalarNumber(objCursor)
 	if ok {
-		return float64(as32), true
+		return asNumber.string(), true
 	}
 
-	asInt, ok := obj.(int)
+	asBool, ok := objCursor.(bool)
 	if ok {
-		return float64(asInt), true
+		return strconv.FormatBool(asBool), true
 	}
 
-	asInt64, ok := obj.(int64)
-	if ok {
-		return float64(asInt64), true
+	if val := reflect.ValueOf(objCursor); val.Kind() == reflect.String && val.Len() > 0 {
+		return val.String(), true
 	}
 
-	asInt32, ok := obj.(int32)
+	return "", false
+}
+
+// CoerceBool will make a best-effort to convert the provided argument to a bool.  It supports bool and *bool, the
+// numbers 0 and 1 of any numeric type, and strings or []byte holding "true", "false", "yes", "no", "on", "off", "1" or
+// "0" (ignoring case and surrounding whitespace), as well as types whose marshaled forms are any of those (see
+// RegisterCoercion).
+func CoerceBool(obj interface{}) (bool, bool) {
+	if b, registered, ok := registeredAs[bool](obj); registered {
+		return b, ok
+	}
+
+	asBool, ok := obj.(bool)
 	if ok {
-		return float64(asInt32), true
+		return asBool, true
+	}
+
+	asBoolPtr, ok := obj.(*bool)
+	if ok && asBoolPtr != nil {
+		return *asBoolPtr, true
 	}
 
 	asString, ok := obj.(string)
 	if ok {
-		floatVal, err := strconv.ParseFloat(asString, 64)
-		if err == nil {
-			return floatVal, true
-		}
+		return parseBool(asString)
 	}
 
 	asBytes, ok := obj.([]byte)
 	if ok {
-		floatVal, err := strconv.ParseFloat(string(asBytes), 64)
-		if err == nil {
-			return floatVal, true
+		return parseBool(string(asBytes))
+	}
+
+	if val := reflect.ValueOf(obj); isNumeric(val) {
+		asFloat64, _ := CoerceFloat64(textOrValue(val))
+		if asFloat64 == 0 || asFloat64 == 1 {
+			return asFloat64 == 1, true
 		}
+		return false, false
 	}
 
-	return 0, false
+	var marshaled bool
+	ok = coerceMarshaled(obj, func(v interface{}) bool {
+		marshaled, ok = CoerceBool(v)
+		return ok
+	})
+	return marshaled, ok
 }
 
-// CoerceString will make a best-effort to convert the provided argument to a string.  It supports string as well as
-// anything supported by CoerceFloat64 and CoerceInt64.  Expect the list of supported argument types to expand.
-func CoerceString(objCursor interface{}) (string, bool) {
-	asString, ok := objCursor.(string)
-	if ok && asString != "" {
-		return asString, true
-	}
-
-	asStringPtr, ok := objCursor.(*string)
-	if ok && asStringPtr != nil {
-		return *asStringPtr, true
+// parseBool reads the strings CoerceBool accepts
+func parseBool(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "yes", "on", "1":
+		return true, true
+	case "false", "no", "off", "0":
+		return false, true
 	}
+	return false, false
+}
 
-	asFloat64, ok := CoerceFloat64(objCursor)
-	if ok {
-		return strconv.FormatFloat(asFloat64, 'f', -1, 64), true
+// getProperty returns the value of prop one level down from obj, as Get sees it.  Get has always treated some missing
+// values as nil (keys missing from a map[string]interface{}, and anything beyond a nil pointer), and reported the rest
+// as errors; lookupProperty is the same without that distinction.
+func getProperty(obj interface{}, prop string, o *options) (interface{}, error) {
+	v, err := lookupProperty(obj, prop, o)
+	if missing, ok := err.(*notFoundError); ok && missing.asNil {
+		return nil, nil
 	}
+	return v, err
+}
 
-	asInt64, ok := CoerceInt64(objCursor)
-	if ok {
-		return strconv.Itoa(int(asInt64)), true
-	}
+// notFoundError is returned by lookupProperty when prop isn't present in obj.  asNil is set for the missing values
+// that Get treats as nil.
+type notFoundError struct {
+	reason string
+	asNil  bool
+}
 
-	asBool, ok := objCursor.(bool)
-	if ok {
-		return strconv.FormatBool(asBool), true
-	}
+func (e *notFoundError) Error() string {
+	return e.reason
+}
 
-	return "", false
+func (e *notFoundError) Is(target error) bool {
+	return target == ErrNotFound
 }
 
-// Loop through this to get properties via dot notation
-func getProperty(obj interface{}, prop string) (interface{}, error) {
+// lookupProperty returns the value of prop one level down from obj, or a *notFoundError if it isn't there
+func lookupProperty(obj interface{}, prop string, o *options) (interface{}, error) {
 	if obj == nil {
-		return nil, nil
+		return nil, &notFoundError{reason: "property " + prop + " of nil not found", asNil: true}
 	}
 
 	// try to get the value without further use of reflections (only works if obj is castable to map[string]interface{})
 	// while the reflections version works for map[string](ANY)
 	asMap, ok := obj.(map[string]interface{})
 	if ok {
-		return asMap[prop], nil
-	}
-
-	kind := reflect.TypeOf(obj).Kind()
+		if v, ok := asMap[prop]; ok {
+			return v, nil
+		}
 
-	if kind == reflect.Slice {
-		return obj, nil // TODO: this kind of seems funny - probably should be nil, nil
-	} else if kind == reflect.Map {
+		if o.caseMode == caseInsensitive {
+			keys := make([]string, 0, len(asMap))
+			for k := range asMap {
+				keys = append(keys, k)
+			}
+			key, ok, err := foldKey(prop, keys)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return asMap[key], nil
+			}
+		}
+		return nil, &notFoundError{reason: "property " + prop + " not found", asNil: true}
+	}
 
-		// the inbound object is a map, but not map[string]interface{}, use reflections to get the value
-		val := reflect.ValueOf(obj)
-		valueOf := val.MapIndex(reflect.ValueOf(prop))
+	// pointers are followed to what they point to
+	val := reflect.ValueOf(obj)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil, &notFoundError{reason: "property " + prop + " of a nil pointer not found", asNil: true}
+		}
+		val = val.Elem()
+	}
 
-		// check for nil (zero of value type)
-		if valueOf == reflect.Zero(reflect.ValueOf(prop).Type()) {
-			return nil, nil
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		return getIndex(val, prop)
+	case reflect.Map:
+		if val.Type().Key().Kind() != reflect.String {
+			return nil, typeMismatch("cannot get property " + prop + " of a map without string keys")
 		}
 
 		// index into the map to get the property's value
-		idx := val.MapIndex(reflect.ValueOf(prop))
+		idx := val.MapIndex(reflect.ValueOf(prop).Convert(val.Type().Key()))
+		if !idx.IsValid() && o.caseMode == caseInsensitive {
+			key, ok, err := foldMapKey(val, prop)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				idx = val.MapIndex(key)
+			}
+		}
 		if !idx.IsValid() {
-			return nil, errors.New("property " + prop + " not found")
+			return nil, &notFoundError{reason: "property " + prop + " not found"}
 		}
 		return idx.Interface(), nil
-	} else if kind == reflect.Ptr {
-		if reflect.ValueOf(obj).IsNil() {
-			return nil, nil
-		}
-		return reflections.GetField(obj, strings.Title(prop))
+	}
+	return getField(val, prop, o)
+}
+
+// getField returns the value of the field addressed by prop in val, which must be a struct
+func getField(val reflect.Value, prop string, o *options) (interface{}, error) {
+	if val.Kind() != reflect.Struct {
+		return nil, typeMismatch("cannot get property " + prop + " of a value of kind " + val.Kind().String())
 	}
 
-	return reflections.GetField(obj, strings.Title(prop))
+	f, err := findField(val.Type(), prop, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// a field promoted through a nil embedded pointer is missing, like a nil pointer's fields
+	field, ok := fieldValue(val, f.index)
+	if !ok {
+		return nil, &notFoundError{reason: "property " + prop + " is behind a nil embedded pointer", asNil: true}
+	}
+	return field.Interface(), nil
 }
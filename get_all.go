@@ -0,0 +1,147 @@
+package dot
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Match is a value found by GetAll, along with the concrete path at which it was found
+type Match struct {
+	Path  string
+	Value interface{}
+}
+
+// GetAll returns every non-nil value in obj at the "location" given by a dot notation property, which may contain
+// wildcard segments ("*"), recursive descent ("..") and filters ("[?expression]").  A wildcard fans out across every
+// key of a map, every field of a struct, and every element of a slice or array, so "orders.*.total" returns the total
+// of each order.  Recursive descent matches the key that follows it at any depth, so "..id" returns every id in obj,
+// including those inside slices.  A filter fans out like a wildcard, but only follows the children it matches, so
+// "items[?price>10 && qty>0].name" returns the name of each item in stock that costs more than 10.  Each match
+// carries its concrete path (e.g. "orders.0.total"), which can be passed to Get or Set.  Children that can't be
+// followed (for instance, an order without a total field) are skipped.  If nothing matches, the last error
+// encountered, if any, is returned.
+func GetAll(obj interface{}, prop string) ([]Match, error) {
+	return std.GetAll(obj, prop)
+}
+
+// GetAll does what the package-level GetAll does, using the Accessor's options
+func (a *Accessor) GetAll(obj interface{}, prop string) ([]Match, error) {
+	segments, err := a.parse(prop)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := a.getAllPath(obj, segments)
+	return matches, withPath(err, prop)
+}
+
+// getAllPath collects every match for segments within obj
+func (a *Accessor) getAllPath(obj interface{}, segments []segment) ([]Match, error) {
+	w := &walker{descending: make(map[visit]bool), options: a.options}
+	w.walk(obj, segments, "")
+	if len(w.matches) == 0 {
+		return nil, w.lastError
+	}
+	return w.matches, nil
+}
+
+var errAppendOnlyInSet = errors.New("append segments may only be used with Set")
+
+// walker collects the matches found while following a path that may fan out
+type walker struct {
+	matches   []Match
+	lastError error
+
+	// descending holds the maps, slices and pointers that recursive descent is currently inside, guarding it against
+	// cycles without skipping values that are merely reachable more than once
+	descending map[visit]bool
+
+	options *options
+}
+
+// visit identifies a map, slice or pointer during recursive descent
+type visit struct {
+	ptr uintptr
+	t   reflect.Type
+}
+
+func (w *walker) walk(obj interface{}, segments []segment, path string) {
+	if obj == nil {
+		return
+	}
+
+	if len(segments) == 0 {
+		w.matches = append(w.matches, Match{Path: path, Value: obj})
+		return
+	}
+
+	seg, rest := segments[0], segments[1:]
+	if seg.descend {
+		w.descend(obj, seg, rest, path)
+		return
+	}
+
+	if seg.fansOut() {
+		for _, k := range childKeys(obj, w.options) {
+			if child, err := getProperty(obj, k, w.options); err == nil && seg.matches(child, w.options) {
+				w.walk(child, rest, joinPath(path, k, w.options.separator))
+			}
+		}
+		return
+	}
+
+	if seg.kind == appendSegment {
+		w.lastError = newPathError(seg, kindOf(obj), errAppendOnlyInSet)
+		return
+	}
+
+	w.step(obj, seg, rest, path)
+}
+
+// descend applies seg at obj and at every node below it
+func (w *walker) descend(obj interface{}, seg segment, rest []segment, path string) {
+	val := reflect.ValueOf(obj)
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if !val.IsNil() {
+			v := visit{ptr: val.Pointer(), t: val.Type()}
+			if w.descending[v] {
+				return
+			}
+			w.descending[v] = true
+			defer delete(w.descending, v)
+		}
+	}
+
+	keys := childKeys(obj, w.options)
+
+	// match here first, ignoring misses, as most nodes won't have the key being searched for
+	if seg.fansOut() {
+		for _, k := range keys {
+			if child, err := getProperty(obj, k, w.options); err == nil && seg.matches(child, w.options) {
+				w.walk(child, rest, joinPath(path, k, w.options.separator))
+			}
+		}
+	} else if seg.kind == keySegment && len(keys) > 0 {
+		if child, err := getProperty(obj, seg.key, w.options); err == nil {
+			w.walk(child, rest, joinPath(path, seg.key, w.options.separator))
+		}
+	}
+
+	// then continue the search in each child
+	for _, k := range keys {
+		if child, err := getProperty(obj, k, w.options); err == nil && child != nil {
+			w.descend(child, seg, rest, joinPath(path, k, w.options.separator))
+		}
+	}
+}
+
+// step follows seg from obj, then continues walking the rest of the segments from there
+func (w *walker) step(obj interface{}, seg segment, rest []segment, path string) {
+	child, err := getProperty(obj, seg.key, w.options)
+	if err != nil {
+		w.lastError = newPathError(seg, kindOf(obj), err)
+		return
+	}
+	w.walk(child, rest, joinPath(path, seg.key, w.options.separator))
+}
@@ -0,0 +1,199 @@
+package dot
+
+import "testing"
+
+func TestGetAll_Wildcard(t *testing.T) {
+	data := map[string]interface{}{
+		"orders": []interface{}{
+			map[string]interface{}{"total": 10},
+			map[string]interface{}{"note": "no total"},
+			map[string]interface{}{"total": 30},
+		},
+	}
+
+	matches, err := GetAll(data, "orders.*.total")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Fatal("expected 2 matches")
+	}
+	if matches[0].Path != "orders.0.total" || matches[0].Value != 10 {
+		t.Fatal("first match was not orders.0.total")
+	}
+	if matches[1].Path != "orders.2.total" || matches[1].Value != 30 {
+		t.Fatal("second match was not orders.2.total")
+	}
+
+	// the concrete paths are usable with Get
+	v, err := Get(data, matches[1].Path)
+	if err != nil || v != 30 {
+		t.Fatal("could not Get a concrete path returned by GetAll")
+	}
+}
+
+func TestGetAll_WildcardMapsAndStructs(t *testing.T) {
+	type Region struct {
+		Name  string
+		Sales float64
+	}
+
+	data := map[string]interface{}{
+		"regions": map[string]Region{
+			"west": {Name: "West", Sales: 4},
+			"east": {Name: "East", Sales: 2},
+		},
+		"example.com": map[string]interface{}{"hits": 9},
+	}
+
+	matches, err := GetAll(data, "regions.*.Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// map keys are visited in sorted order
+	if len(matches) != 2 || matches[0].Path != "regions.east.Name" || matches[1].Value != "West" {
+		t.Fatal("did not fan out across the regions map")
+	}
+
+	matches, err = GetAll(data, "regions.west.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 || matches[0].Path != "regions.west.Name" || matches[1].Path != "regions.west.Sales" {
+		t.Fatal("did not fan out across the struct fields")
+	}
+
+	// keys containing periods are quoted in the returned paths
+	matches, err = GetAll(data, "*.hits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Path != "[\"example.com\"].hits" {
+		t.Fatal("path of key containing a period was not quoted")
+	}
+}
+
+func TestGetAll_NoMatches(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []string{"a"},
+	}
+
+	matches, err := GetAll(data, "items.3")
+	if err == nil {
+		t.Fatal("did not get an error when nothing could be followed")
+	}
+	if len(matches) != 0 {
+		t.Fatal("got matches for an out of range index")
+	}
+
+	matches, err = GetAll(nil, "*")
+	if err != nil || len(matches) != 0 {
+		t.Fatal("GetAll on nil should return nothing")
+	}
+}
+
+func TestGetAll_RecursiveDescent(t *testing.T) {
+	type Error struct {
+		Code  int
+		Cause *Error
+	}
+
+	data := map[string]interface{}{
+		"id": "root",
+		"children": []interface{}{
+			map[string]interface{}{"id": "a"},
+			map[string]interface{}{
+				"id": "b",
+				"meta": map[string]interface{}{
+					"id": "b-meta",
+				},
+			},
+		},
+		"error": Error{Code: 500, Cause: &Error{Code: 404}},
+	}
+
+	matches, err := GetAll(data, "..id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 4 {
+		t.Fatal("expected 4 ids")
+	}
+
+	paths := make([]string, len(matches))
+	for i, m := range matches {
+		paths[i] = m.Path
+	}
+	if !contains(paths, "id") || !contains(paths, "children.0.id") || !contains(paths, "children.1.id") ||
+		!contains(paths, "children.1.meta.id") {
+		t.Fatal("did not find every id")
+	}
+
+	// descent reaches struct fields, including through pointers
+	matches, err = GetAll(data, "..Code")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 || matches[0].Path != "error.Code" || matches[1].Path != "error.Cause.Code" {
+		t.Fatal("did not descend through structs")
+	}
+
+	// descent can follow a prefix and be followed by more segments
+	matches, err = GetAll(data, "children..meta.id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Value != "b-meta" {
+		t.Fatal("did not find children..meta.id")
+	}
+}
+
+func TestGetAll_RecursiveDescentCycle(t *testing.T) {
+	type Node struct {
+		Name string
+		Next *Node
+	}
+
+	n := &Node{Name: "loop"}
+	n.Next = n
+
+	matches, err := GetAll(n, "..Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 {
+		t.Fatal("pointer cycle was not guarded against")
+	}
+}
+
+func TestGetAll_RecursiveDescentSharedAndCyclicContainers(t *testing.T) {
+	type Address struct {
+		City string
+	}
+
+	// a pointer reachable from two branches is searched from both
+	p := &Address{City: "Oslo"}
+	matches, err := GetAll(map[string]interface{}{"x": p, "y": p}, "..City")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 || matches[0].Path != "x.City" || matches[1].Path != "y.City" {
+		t.Fatal("shared pointer was not searched from both branches, got", matches)
+	}
+
+	// maps and slices that contain themselves are guarded against
+	m := map[string]interface{}{"id": 1}
+	m["self"] = m
+	list := []interface{}{map[string]interface{}{"id": 2}, nil}
+	list[1] = list
+	m["list"] = list
+
+	matches, err = GetAll(m, "..id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Fatal("cyclic map or slice was not guarded against, got", matches)
+	}
+}
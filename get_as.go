@@ -0,0 +1,69 @@
+package dot
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// GetAs does what Get does, returning the value as a T.  Like the typed getters, it continues through props until it
+// gets a non-nil value that is a T or can be converted to one, so GetAs[int](obj, "port", "legacyPort") finds the
+// first of them that holds a number.  Values are converted as a converting Set converts them (see WithConversion),
+// so the float64 8080 that encoding/json produces becomes the int 8080, but 80.5 is rejected rather than truncated.
+// If no prop has such a value, the zero T is returned along with the most recent error, which is nil when props were
+// merely missing or nil, as with Get.
+func GetAs[T any](obj interface{}, props ...string) (T, error) {
+	return GetAsWith[T](std, obj, props...)
+}
+
+// GetAsWith does what GetAs does, using the Accessor's options, such as its separator.  Methods can't have type
+// parameters, so it takes the Accessor as its first argument instead.
+func GetAsWith[T any](a *Accessor, obj interface{}, props ...string) (T, error) {
+	v, _, err := getFirst(a, obj, props, coerceTo[T])
+	return v, err
+}
+
+// GetOr does what GetAs does, except it returns def when no prop has a value that is, or can be converted to, a T
+func GetOr[T any](obj interface{}, def T, props ...string) T {
+	return GetOrWith(std, obj, def, props...)
+}
+
+// GetOrWith does what GetOr does, using the Accessor's options
+func GetOrWith[T any](a *Accessor, obj interface{}, def T, props ...string) T {
+	v, found, _ := getFirst(a, obj, props, coerceTo[T])
+	if !found {
+		return def
+	}
+	return v
+}
+
+// MustGet does what GetAs does, except it panics when no prop has a value that is, or can be converted to, a T.  It
+// is intended for values that are known to be present, such as in tests.
+func MustGet[T any](obj interface{}, props ...string) T {
+	return MustGetWith[T](std, obj, props...)
+}
+
+// MustGetWith does what MustGet does, using the Accessor's options
+func MustGetWith[T any](a *Accessor, obj interface{}, props ...string) T {
+	v, found, err := getFirst(a, obj, props, coerceTo[T])
+	if err != nil {
+		panic(fmt.Sprintf("dot: MustGet(%v): %v", props, err))
+	}
+	if !found {
+		panic(fmt.Sprintf("dot: MustGet(%v): no value found", props))
+	}
+	return v
+}
+
+// coerceTo converts v to a T for the generic getters
+func coerceTo[T any](v interface{}) (T, error) {
+	if t, ok := v.(T); ok {
+		return t, nil
+	}
+
+	var zero T
+	converted, err := convertValue(reflect.ValueOf(v), reflect.TypeOf(&zero).Elem())
+	if err != nil {
+		return zero, err
+	}
+	return converted.Interface().(T), nil
+}
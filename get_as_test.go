@@ -0,0 +1,99 @@
+package dot
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestGetAs(t *testing.T) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"port": 8080, "ratio": 0.5, "name": "svc", "debug": "true", "tags": ["a"]}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if port, err := GetAs[int](doc, "port"); err != nil || port != 8080 {
+		t.Fatal("float64 was not coerced to int:", port, err)
+	}
+	if name, err := GetAs[string](doc, "name"); err != nil || name != "svc" {
+		t.Fatal("string was not returned:", name, err)
+	}
+	if debug, err := GetAs[bool](doc, "debug"); err != nil || !debug {
+		t.Fatal("string was not coerced to bool:", debug, err)
+	}
+	if tags, err := GetAs[[]interface{}](doc, "tags"); err != nil || len(tags) != 1 {
+		t.Fatal("slice was not returned:", tags, err)
+	}
+
+	// fallback continues past missing values and values that can't be coerced
+	if port, err := GetAs[int](doc, "missing", "ratio", "port"); err != nil || port != 8080 {
+		t.Fatal("did not fall back to port:", port, err)
+	}
+
+	_, err := GetAs[int](doc, "ratio")
+	var convErr *ConversionError
+	if !errors.As(err, &convErr) || !errors.Is(err, ErrTypeMismatch) {
+		t.Fatal("expected a ConversionError for a lossy conversion, got", err)
+	}
+
+	// missing values aren't errors, as with Get
+	if v, err := GetAs[int](doc, "missing"); err != nil || v != 0 {
+		t.Fatal("missing value was not the zero value without error:", v, err)
+	}
+
+	type Server struct {
+		Port int
+	}
+	if _, err := GetAs[int](Server{}, "Host"); !errors.Is(err, ErrNotFound) {
+		t.Fatal("expected ErrNotFound for a missing field, got", err)
+	}
+}
+
+func TestGetOr(t *testing.T) {
+	doc := map[string]interface{}{"port": "8080", "host": nil}
+
+	if GetOr(doc, 80, "port") != 8080 {
+		t.Fatal("string was not coerced to int")
+	}
+	if GetOr(doc, "localhost", "host") != "localhost" {
+		t.Fatal("default was not used for a nil value")
+	}
+	if GetOr(doc, 1.5, "missing") != 1.5 {
+		t.Fatal("default was not used for a missing value")
+	}
+	if GetOr(doc, false, "port") {
+		t.Fatal("default was not used for a value that can't be coerced")
+	}
+}
+
+func TestMustGet(t *testing.T) {
+	doc := map[string]interface{}{"count": int64(3)}
+	if MustGet[int32](doc, "count") != 3 {
+		t.Fatal("int64 was not coerced to int32")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustGet did not panic for a missing value")
+		}
+	}()
+	MustGet[int](doc, "missing")
+}
+
+func TestGetAsWith(t *testing.T) {
+	slash := New(WithSeparator("/"))
+	doc := map[string]interface{}{"server": map[string]interface{}{"port": 8080.0, "host.name": "example.com"}}
+
+	if port, err := GetAsWith[int](slash, doc, "server/port"); err != nil || port != 8080 {
+		t.Error("GetAsWith did not use the separator, got", port, err)
+	}
+	if GetOrWith(slash, doc, "localhost", "server/host.name") != "example.com" {
+		t.Error("GetOrWith did not use the separator")
+	}
+	if GetOrWith(slash, doc, 80, "server.port") != 80 {
+		t.Error("GetOrWith did not use the default for a path with another separator")
+	}
+	if MustGetWith[string](slash, doc, "server/host.name") != "example.com" {
+		t.Error("MustGetWith did not use the separator")
+	}
+}
@@ -328,4 +328,46 @@ func TestCoerceStringFromBool(t *testing.T) {
 	if v, ok := CoerceString(false); ok == false || v != "false" {
 		t.Error("result did not equal 'false' when coercing string")
 	}
-}
\ No newline at end of file
+}
+
+func TestGetBool(t *testing.T) {
+	data := make(map[string]interface{})
+	data["flag"] = "yes"
+	data["json"] = true
+	data["bad"] = "maybe"
+	data["count"] = 2.0
+	if !GetBool(data, "flag") || !GetBool(data, "json") {
+		t.Error("result was not true")
+	}
+
+	// fallback continues past values that can't be coerced
+	if !GetBool(data, "a", "bad", "count", "flag") {
+		t.Error("result did not fall back to flag")
+	}
+
+	if GetBool(data, "bad") {
+		t.Error("result was not false for a value that can't be coerced")
+	}
+}
+
+func TestCoerceBool(t *testing.T) {
+	on := true
+	for _, value := range []interface{}{true, &on, 1, int8(1), uint(1), 1.0, "true", "Yes", " on ", "1", []byte("TRUE")} {
+		if v, ok := CoerceBool(value); !ok || !v {
+			t.Error("did not coerce", value, "to true")
+		}
+	}
+
+	for _, value := range []interface{}{false, 0, 0.0, "false", "NO", "off", "0", []byte("no")} {
+		if v, ok := CoerceBool(value); !ok || v {
+			t.Error("did not coerce", value, "to false")
+		}
+	}
+
+	var nilBool *bool
+	for _, value := range []interface{}{nil, nilBool, 2, 0.5, "maybe", "", []string{"true"}} {
+		if _, ok := CoerceBool(value); ok {
+			t.Error("coerced", value, "to a bool")
+		}
+	}
+}
@@ -1,5 +1,3 @@
 module github.com/markdicksonjr/dot
 
-go 1.12
-
-require github.com/oleiade/reflections v1.0.0
+go 1.18
@@ -0,0 +1,51 @@
+package dot
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// IndexOutOfRangeError is returned when a numeric path segment addresses an element beyond the bounds of a slice or
+// array.  Index is the index as requested (which may be negative), Length is the length of the slice or array at the
+// time of access.
+type IndexOutOfRangeError struct {
+	Index  int
+	Length int
+}
+
+func (e *IndexOutOfRangeError) Error() string {
+	return "index " + strconv.Itoa(e.Index) + " out of range for length " + strconv.Itoa(e.Length)
+}
+
+// Is makes errors.Is match the error to ErrIndexOutOfRange
+func (e *IndexOutOfRangeError) Is(target error) bool {
+	return target == ErrIndexOutOfRange
+}
+
+// resolveIndex converts prop to a position within a slice or array of the given length.  Negative indices count back
+// from the end, so -1 is the last element.
+func resolveIndex(prop string, length int) (int, error) {
+	i, err := strconv.Atoi(prop)
+	if err != nil {
+		return 0, typeMismatch("property " + prop + " is not a valid index")
+	}
+
+	resolved := i
+	if resolved < 0 {
+		resolved += length
+	}
+
+	if resolved < 0 || resolved >= length {
+		return 0, &IndexOutOfRangeError{Index: i, Length: length}
+	}
+	return resolved, nil
+}
+
+// getIndex returns the element at the position given by prop from val, which must be a slice or array
+func getIndex(val reflect.Value, prop string) (interface{}, error) {
+	i, err := resolveIndex(prop, val.Len())
+	if err != nil {
+		return nil, err
+	}
+	return val.Index(i).Interface(), nil
+}
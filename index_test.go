@@ -0,0 +1,134 @@
+package dot
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGet_SliceIndex(t *testing.T) {
+	type Watcher struct {
+		Name string
+	}
+
+	type Issue struct {
+		Watchers []Watcher
+		Labels   [2]string
+	}
+
+	issue := Issue{
+		Watchers: []Watcher{
+			{Name: "ann"},
+			{Name: "bob"},
+		},
+		Labels: [2]string{"bug", "p1"},
+	}
+
+	name, err := Get(issue, "Watchers.1.Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "bob" {
+		t.Fatal("Watchers.1.Name was not bob")
+	}
+
+	// arrays index the same way slices do
+	if GetString(issue, "Labels.0") != "bug" {
+		t.Fatal("Labels.0 was not bug")
+	}
+
+	// pointers to slices are indexed through
+	if GetString(&issue.Watchers, "0.Name") != "ann" {
+		t.Fatal("0.Name via slice pointer was not ann")
+	}
+
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"price": 4.5, "qty": 2},
+			map[string]interface{}{"price": "7.25", "qty": int64(9)},
+		},
+	}
+
+	if GetFloat64(data, "items.1.price") != 7.25 {
+		t.Fatal("items.1.price was not 7.25")
+	}
+
+	if GetInt64(data, "items.0.qty") != 2 {
+		t.Fatal("items.0.qty was not 2")
+	}
+
+	// fallback continues past a bad index
+	if GetInt64(data, "items.5.qty", "items.1.qty") != 9 {
+		t.Fatal("fallback past an out of range index did not work")
+	}
+}
+
+func TestGet_SliceIndexErrors(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []string{"a", "b"},
+	}
+
+	_, err := Get(data, "items.2")
+	if err == nil {
+		t.Fatal("did not get an error for an out of range index")
+	}
+
+	var rangeErr *IndexOutOfRangeError
+	if !errors.As(err, &rangeErr) {
+		t.Fatal("error was not an IndexOutOfRangeError")
+	}
+	if rangeErr.Index != 2 || rangeErr.Length != 2 {
+		t.Fatal("IndexOutOfRangeError did not report the index and length")
+	}
+
+	if _, err := Get(data, "items.x"); err == nil {
+		t.Fatal("did not get an error for a non-numeric index")
+	}
+}
+
+func TestGet_NegativeIndex(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": "first"},
+			map[string]interface{}{"id": "middle"},
+			map[string]interface{}{"id": "last"},
+		},
+	}
+
+	if GetString(data, "items.-1.id") != "last" {
+		t.Fatal("items.-1.id was not last")
+	}
+
+	if GetString(data, "items.-3.id") != "first" {
+		t.Fatal("items.-3.id was not first")
+	}
+
+	_, err := Get(data, "items.-4.id")
+	var rangeErr *IndexOutOfRangeError
+	if !errors.As(err, &rangeErr) {
+		t.Fatal("did not get an IndexOutOfRangeError for items.-4")
+	}
+	if rangeErr.Index != -4 || rangeErr.Length != 3 {
+		t.Fatal("IndexOutOfRangeError did not report the requested index")
+	}
+}
+
+func TestSet_NegativeIndex(t *testing.T) {
+	data := map[string]interface{}{
+		"history": []interface{}{
+			map[string]interface{}{"seen": false},
+			map[string]interface{}{"seen": false},
+		},
+	}
+
+	if err := Set(data, "history.-1.seen", true); err != nil {
+		t.Fatal(err)
+	}
+
+	if seen, _ := Get(data, "history.1.seen"); seen != true {
+		t.Fatal("history.-1.seen was not set on the last element")
+	}
+
+	if seen, _ := Get(data, "history.0.seen"); seen != false {
+		t.Fatal("history.0.seen should not have changed")
+	}
+}
@@ -3,11 +3,19 @@ package dot
 import (
 	"encoding/json"
 	"reflect"
+	"sort"
+	"strconv"
 )
 
 // Keys will get the list of keys for an arbitrary structure (non-recursively).  In the result below, the result will
-// be ["A", "B"], though it's best to not assume the elements are ordered.
+// be ["A", "B"], though it's best to not assume the elements are ordered.  Each key is returned as a path that can be
+// passed to Get or Set, so keys that contain periods, brackets or quotes are quoted, as in `["example.com"]`.
 func Keys(obj interface{}, parentPath ...string) []string {
+	return std.Keys(obj, parentPath...)
+}
+
+// Keys does what the package-level Keys does, using the Accessor's options
+func (a *Accessor) Keys(obj interface{}, parentPath ...string) []string {
 	if obj == nil {
 		return nil
 	}
@@ -21,11 +29,7 @@ func Keys(obj interface{}, parentPath ...string) []string {
 	if ok {
 		var keys []string
 		for k := range asMap {
-			adjustedKey := k
-			if len(strParentPath) > 0 {
-				adjustedKey = strParentPath + "." + adjustedKey
-			}
-			keys = append(keys, adjustedKey)
+			keys = append(keys, joinPath(strParentPath, k, a.options.separator))
 		}
 		return keys
 	}
@@ -37,32 +41,26 @@ func Keys(obj interface{}, parentPath ...string) []string {
 	}()
 	var keys []string
 	fields := reflect.TypeOf(obj)
-	if fields.Kind() == reflect.Struct {
-		num := fields.NumField()
-		for i := 0; i < num; i++ {
-			field := fields.Field(i)
-			keys = append(keys, field.Name)
-		}
-	} else if fields.Kind() == reflect.Ptr {
+	if fields.Kind() == reflect.Ptr {
 		fields = fields.Elem()
-		num := fields.NumField()
-		for i := 0; i < num; i++ {
-			field := fields.Field(i)
-			keys = append(keys, field.Name)
-		}
 	}
-
-	for i, k := range keys {
-		adjustedKey := k
-		if len(strParentPath) > 0 {
-			adjustedKey = strParentPath + "." + adjustedKey
+	if fields.Kind() == reflect.Struct {
+		for _, field := range structFields(fields, a.options) {
+			if field.embedded {
+				continue
+			}
+			keys = append(keys, joinPath(strParentPath, field.name, a.options.separator))
 		}
-		keys[i] = adjustedKey
 	}
 	return keys
 }
 
 func KeysWithoutReflection(obj interface{}, parentPath ...string) []string {
+	return std.KeysWithoutReflection(obj, parentPath...)
+}
+
+// KeysWithoutReflection does what the package-level KeysWithoutReflection does, using the Accessor's options
+func (a *Accessor) KeysWithoutReflection(obj interface{}, parentPath ...string) []string {
 	if obj == nil {
 		return []string{}
 	}
@@ -76,11 +74,7 @@ func KeysWithoutReflection(obj interface{}, parentPath ...string) []string {
 	if ok {
 		var keys []string
 		for k := range asMap {
-			adjustedKey := k
-			if len(strParentPath) > 0 {
-				adjustedKey = strParentPath + "." + adjustedKey
-			}
-			keys = append(keys, adjustedKey)
+			keys = append(keys, joinPath(strParentPath, k, a.options.separator))
 		}
 		return keys
 	}
@@ -99,11 +93,7 @@ func KeysWithoutReflection(obj interface{}, parentPath ...string) []string {
 
 	var keys []string
 	for k := range asMap {
-		adjustedKey := k
-		if len(strParentPath) > 0 {
-			adjustedKey = strParentPath + "." + adjustedKey
-		}
-		keys = append(keys, adjustedKey)
+		keys = append(keys, joinPath(strParentPath, k, a.options.separator))
 	}
 	return keys
 }
@@ -111,23 +101,25 @@ func KeysWithoutReflection(obj interface{}, parentPath ...string) []string {
 // KeysRecursive is just like Keys, only recursive.  The ordering of elements in the resulting slice is not to be
 // assumed at any time
 func KeysRecursive(obj interface{}, parentPath ...string) []string {
+	return std.KeysRecursive(obj, parentPath...)
+}
+
+// KeysRecursive does what the package-level KeysRecursive does, using the Accessor's options
+func (a *Accessor) KeysRecursive(obj interface{}, parentPath ...string) []string {
 	strParentPath := ""
 	if len(parentPath) > 0 {
 		strParentPath = parentPath[0]
 	}
 
 	var allKeys []string
-	keys := Keys(obj)
+	keys := a.Keys(obj)
 	for _, k := range keys {
-		adjustedChildPath := k
-		if len(strParentPath) > 0 {
-			adjustedChildPath = strParentPath + "." + k
-		}
+		adjustedChildPath := joinFormatted(strParentPath, k, a.options.separator)
 		allKeys = append(allKeys, adjustedChildPath)
 
-		v, _ := Get(obj, k)
+		v, _ := a.Get(obj, k)
 		if v != nil {
-			allKeys = append(allKeys, KeysRecursive(v, adjustedChildPath)...)
+			allKeys = append(allKeys, a.KeysRecursive(v, adjustedChildPath)...)
 		}
 	}
 
@@ -136,6 +128,11 @@ func KeysRecursive(obj interface{}, parentPath ...string) []string {
 
 // KeysRecursiveLeaves is like KeysRecursive, except it returns only items with no "children"
 func KeysRecursiveLeaves(obj interface{}, parentPath ...string) []string {
+	return std.KeysRecursiveLeaves(obj, parentPath...)
+}
+
+// KeysRecursiveLeaves does what the package-level KeysRecursiveLeaves does, using the Accessor's options
+func (a *Accessor) KeysRecursiveLeaves(obj interface{}, parentPath ...string) []string {
 	if obj == nil {
 		return nil
 	}
@@ -146,16 +143,13 @@ func KeysRecursiveLeaves(obj interface{}, parentPath ...string) []string {
 	}
 
 	var allKeys []string
-	keys := Keys(obj)
+	keys := a.Keys(obj)
 	for _, k := range keys {
-		adjustedChildPath := k
-		if len(strParentPath) > 0 {
-			adjustedChildPath = strParentPath + "." + k
-		}
+		adjustedChildPath := joinFormatted(strParentPath, k, a.options.separator)
 
-		v, _ := Get(obj, k)
+		v, _ := a.Get(obj, k)
 		if v != nil {
-			leaves := KeysRecursiveLeaves(v, adjustedChildPath)
+			leaves := a.KeysRecursiveLeaves(v, adjustedChildPath)
 			if len(leaves) == 0 {
 				allKeys = append(allKeys, adjustedChildPath)
 			} else {
@@ -168,3 +162,47 @@ func KeysRecursiveLeaves(obj interface{}, parentPath ...string) []string {
 
 	return allKeys
 }
+
+// childKeys lists the keys that address each direct child of obj, including the indices of slices and arrays.  Map
+// keys are sorted so that callers fanning out across children see them in a stable order.
+func childKeys(obj interface{}, o *options) []string {
+	if obj == nil {
+		return nil
+	}
+
+	val := reflect.ValueOf(obj)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		keys := make([]string, val.Len())
+		for i := range keys {
+			keys[i] = strconv.Itoa(i)
+		}
+		return keys
+	case reflect.Map:
+		if val.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		var keys []string
+		for _, k := range val.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+		return keys
+	case reflect.Struct:
+		var keys []string
+		for _, field := range structFields(val.Type(), o) {
+			if !field.embedded {
+				keys = append(keys, field.name)
+			}
+		}
+		return keys
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package dot
+
+import "errors"
+
+// Lookup returns the value in obj at prop, along with whether it was found there.  Unlike Get, it tells a value that is
+// present but nil apart from one that is missing, in the same way for every kind of container: found is false for a
+// missing map key or struct field, an index beyond the end of a slice, and anything beyond a nil pointer, interface or
+// map, or beyond a missing value.  A map key or struct field that is present but nil is found.  err is only set when
+// prop can't be followed at all, such as when it is malformed or indexes into a number.
+func Lookup(obj interface{}, prop string) (interface{}, bool, error) {
+	return std.Lookup(obj, prop)
+}
+
+// Lookup does what the package-level Lookup does, using the Accessor's options
+func (a *Accessor) Lookup(obj interface{}, prop string) (interface{}, bool, error) {
+	segments, err := a.parse(prop)
+	if err != nil {
+		return nil, false, err
+	}
+	v, found, err := lookupPath(obj, segments, a.options)
+	return v, found, withPath(err, prop)
+}
+
+// Exists returns true if Lookup finds prop in obj, even if the value there is nil
+func Exists(obj interface{}, prop string) bool {
+	return std.Exists(obj, prop)
+}
+
+// Exists does what the package-level Exists does, using the Accessor's options
+func (a *Accessor) Exists(obj interface{}, prop string) bool {
+	_, found, err := a.Lookup(obj, prop)
+	return found && err == nil
+}
+
+// lookupPath follows each of the segments from obj, returning the value at the end and whether it was found
+func lookupPath(obj interface{}, segments []segment, o *options) (interface{}, bool, error) {
+	v, err := followPath(obj, segments, o)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return v, true, nil
+}
+
+// followPath is lookupPath, except that a missing value is reported as a *PathError for the segment that was missing
+func followPath(obj interface{}, segments []segment, o *options) (interface{}, error) {
+	for _, seg := range segments {
+		var next interface{}
+		err := checkSingleSegment(obj, seg)
+		if err == nil {
+			next, err = lookupProperty(obj, seg.key, o)
+		}
+
+		if err != nil {
+			return nil, newPathError(seg, kindOf(obj), err)
+		}
+		obj = next
+	}
+	return obj, nil
+}
+
+// isNotFound returns true if err reports that something is missing, rather than that it couldn't be looked for
+func isNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || errors.Is(err, ErrIndexOutOfRange)
+}
@@ -0,0 +1,94 @@
+package dot
+
+import "testing"
+
+func TestLookup_Maps(t *testing.T) {
+	obj := map[string]interface{}{
+		"null":   nil,
+		"nested": map[string]int{"one": 1},
+	}
+
+	if v, found, err := Lookup(obj, "null"); err != nil || !found || v != nil {
+		t.Fatal("present nil was not found:", v, found, err)
+	}
+	if _, found, err := Lookup(obj, "absent"); err != nil || found {
+		t.Fatal("absent key was found:", found, err)
+	}
+	if v, found, err := Lookup(obj, "nested.one"); err != nil || !found || v != 1 {
+		t.Fatal("nested key was not found:", v, found, err)
+	}
+	if _, found, err := Lookup(obj, "nested.two"); err != nil || found {
+		t.Fatal("absent key of a typed map was found:", found, err)
+	}
+
+	// nothing is found beyond a nil or missing value
+	if _, found, err := Lookup(obj, "null.child"); err != nil || found {
+		t.Fatal("child of nil was found:", found, err)
+	}
+	if _, found, err := Lookup(obj, "absent.child"); err != nil || found {
+		t.Fatal("child of a missing value was found:", found, err)
+	}
+
+	if _, _, err := Lookup(obj, "nested.one.deeper"); err == nil {
+		t.Fatal("expected an error looking into a number")
+	}
+	if _, _, err := Lookup(obj, "a["); err == nil {
+		t.Fatal("expected an error for a malformed path")
+	}
+}
+
+func TestLookup_StructsAndPointers(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type User struct {
+		Name    string
+		Address *Address
+		Tags    []string
+	}
+
+	u := &User{Tags: []string{"a"}}
+
+	if v, found, err := Lookup(u, "Address"); err != nil || !found || v.(*Address) != nil {
+		t.Fatal("nil pointer field was not found:", v, found, err)
+	}
+	if _, found, err := Lookup(u, "Address.City"); err != nil || found {
+		t.Fatal("field beyond a nil pointer was found:", found, err)
+	}
+	if _, found, err := Lookup(u, "Missing"); err != nil || found {
+		t.Fatal("missing field was found:", found, err)
+	}
+	if v, found, err := Lookup(u, "Name"); err != nil || !found || v != "" {
+		t.Fatal("zero field was not found:", v, found, err)
+	}
+
+	if v, found, err := Lookup(u, "Tags.-1"); err != nil || !found || v != "a" {
+		t.Fatal("slice element was not found:", v, found, err)
+	}
+	if _, found, err := Lookup(u, "Tags.1"); err != nil || found {
+		t.Fatal("index beyond the end was found:", found, err)
+	}
+	if _, _, err := Lookup(u, "Tags.first"); err == nil {
+		t.Fatal("expected an error for a key that isn't an index")
+	}
+
+	var nilUser *User
+	if _, found, err := Lookup(nilUser, "Name"); err != nil || found {
+		t.Fatal("field of a nil pointer was found:", found, err)
+	}
+}
+
+func TestExists(t *testing.T) {
+	obj := map[string]interface{}{"null": nil, "list": []interface{}{nil}}
+
+	if !Exists(obj, "null") || !Exists(obj, "list.0") {
+		t.Fatal("present nil values do not exist")
+	}
+	if Exists(obj, "absent") || Exists(obj, "list.1") || Exists(obj, "list[") {
+		t.Fatal("missing values exist")
+	}
+
+	if !MustCompile("null").Exists(obj) {
+		t.Fatal("compiled path does not find a present nil value")
+	}
+}
@@ -0,0 +1,293 @@
+package dot
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// Copy sets the value at the path to in obj to a deep copy of the value at the path from, so that changes to one don't
+// show up in the other.  If there is no value at from, as Lookup sees it, the error matches ErrNotFound.  Missing
+// nodes on the way to "to" are allocated just as Set allocates them.
+func Copy(obj interface{}, from string, to string, opts ...Option) error {
+	return std.Copy(obj, from, to, opts...)
+}
+
+// Copy does what the package-level Copy does, using the Accessor's options along with any given here
+func (a *Accessor) Copy(obj interface{}, from string, to string, opts ...Option) error {
+	return a.CopyTo(obj, from, obj, to, opts...)
+}
+
+// CopyTo is like Copy, except the copy is set at the path to in dst rather than in src
+func CopyTo(src interface{}, from string, dst interface{}, to string, opts ...Option) error {
+	return std.CopyTo(src, from, dst, to, opts...)
+}
+
+// CopyTo does what the package-level CopyTo does, using the Accessor's options along with any given here
+func (a *Accessor) CopyTo(src interface{}, from string, dst interface{}, to string, opts ...Option) error {
+	a, err := a.with(opts)
+	if err != nil {
+		return err
+	}
+	value, err := a.lookupSource(src, from)
+	if err != nil {
+		return err
+	}
+	return a.Set(dst, to, deepCopy(value))
+}
+
+// Move moves the value at the path from in obj to the path to, removing it from where it was as Delete does.  This
+// renames a key, as in Move(doc, "user.name", "profile.displayName").  Missing nodes on the way to "to" are allocated
+// just as Set allocates them.  If there is no value at from, as Lookup sees it, the error matches ErrNotFound.  If the
+// value can't be set at "to", it is put back where it was.  Moving a slice element to another index of the same slice
+// shifts the elements between the two indices, as a JSON Patch move does, so the element ends up at the index to and
+// nothing is overwritten: moving "items.0" to "items.1" in [a b c] gives [b a c].
+func Move(obj interface{}, from string, to string, opts ...Option) error {
+	return std.Move(obj, from, to, opts...)
+}
+
+// Move does what the package-level Move does, using the Accessor's options along with any given here
+func (a *Accessor) Move(obj interface{}, from string, to string, opts ...Option) error {
+	return a.MoveTo(obj, from, obj, to, opts...)
+}
+
+// MoveTo is like Move, except the value is set at the path to in dst rather than in src
+func MoveTo(src interface{}, from string, dst interface{}, to string, opts ...Option) error {
+	return std.MoveTo(src, from, dst, to, opts...)
+}
+
+// MoveTo does what the package-level MoveTo does, using the Accessor's options along with any given here
+func (a *Accessor) MoveTo(src interface{}, from string, dst interface{}, to string, opts ...Option) error {
+	a, err := a.with(opts)
+	if err != nil {
+		return err
+	}
+	if sameObject(src, dst) {
+		if moved, err := a.moveWithinSlice(src, from, to); moved || err != nil {
+			return err
+		}
+	}
+
+	value, err := a.lookupSource(src, from)
+	if err != nil {
+		return err
+	}
+
+	// removing a slice element shifts the rest down, so the slice as it was is put back instead.  Delete builds a new
+	// slice rather than shifting elements within this one, so it is left intact.
+	restore := func() { _ = a.Set(src, from, value) }
+	if slice, segments, ok := a.parentSlice(src, from); ok {
+		restore = func() { _ = a.replaceSlice(src, segments[:len(segments)-1], slice) }
+	}
+
+	// delete first, so moving a value into or out of itself (e.g. "a" to "a.b") works
+	removed, err := a.Delete(src, from)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Set(dst, to, value); err != nil {
+		if removed {
+			restore()
+		}
+		return err
+	}
+	return nil
+}
+
+// moveWithinSlice moves the element at from to the index to of the same slice, returning false if from and to aren't
+// indices of one slice.  The elements between them shift over, rather than the one at to being overwritten.
+func (a *Accessor) moveWithinSlice(obj interface{}, from string, to string) (bool, error) {
+	slice, fromSegments, ok := a.parentSlice(obj, from)
+	if !ok {
+		return false, nil
+	}
+	parent := fromSegments[:len(fromSegments)-1]
+
+	toSegments, err := a.parseWritable(to, "move")
+	if err != nil || len(toSegments) != len(fromSegments) || !sameParent(parent, toSegments) {
+		return false, nil
+	}
+	toSegment := toSegments[len(parent)]
+	if !isIndexSegment(toSegment) {
+		return false, nil
+	}
+
+	// a missing source is left for MoveTo to report
+	i, err := resolveIndex(fromSegments[len(parent)].key, slice.Len())
+	if err != nil {
+		return false, nil
+	}
+
+	// the index to is where the element ends up, so it is resolved against the slice as it is, not as it is without
+	// the element
+	j, err := resolveIndex(toSegment.key, slice.Len())
+	if err != nil {
+		return true, withPath(newPathError(toSegment, reflect.Slice, err), to)
+	}
+
+	// build a new slice, as Delete does, rather than shifting elements within a backing array that others may share
+	rest := reflect.MakeSlice(slice.Type(), 0, slice.Len()-1)
+	rest = reflect.AppendSlice(rest, slice.Slice(0, i))
+	rest = reflect.AppendSlice(rest, slice.Slice(i+1, slice.Len()))
+
+	moved := reflect.MakeSlice(slice.Type(), 0, slice.Len())
+	moved = reflect.AppendSlice(moved, rest.Slice(0, j))
+	moved = reflect.Append(moved, slice.Index(i))
+	moved = reflect.AppendSlice(moved, rest.Slice(j, rest.Len()))
+	return true, withPath(a.replaceSlice(obj, parent, moved), from)
+}
+
+// parentSlice returns the slice that prop indexes into within obj, along with the segments of prop, or false if prop
+// doesn't address an element of a slice
+func (a *Accessor) parentSlice(obj interface{}, prop string) (reflect.Value, []segment, bool) {
+	segments, err := a.parseWritable(prop, "move")
+	if err != nil || len(segments) == 0 || !isIndexSegment(segments[len(segments)-1]) {
+		return reflect.Value{}, nil, false
+	}
+
+	parent, found, err := lookupPath(obj, segments[:len(segments)-1], a.options)
+	if err != nil || !found {
+		return reflect.Value{}, nil, false
+	}
+
+	val := reflect.ValueOf(parent)
+	for len(segments) == 1 && val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	return val, segments, val.Kind() == reflect.Slice
+}
+
+// replaceSlice stores slice at the location given by segments within obj, which is obj itself if there are none
+func (a *Accessor) replaceSlice(obj interface{}, segments []segment, slice reflect.Value) error {
+	if len(segments) > 0 {
+		return a.setPath(obj, segments, slice.Interface())
+	}
+
+	root := reflect.ValueOf(obj)
+	for root.Kind() == reflect.Ptr && !root.IsNil() {
+		root = root.Elem()
+	}
+	if !root.CanSet() {
+		return notAddressable("slice must be passed as a pointer to move within it")
+	}
+	root.Set(slice)
+	return nil
+}
+
+// isIndexSegment returns true if seg is a plain numeric segment, which may index a slice
+func isIndexSegment(seg segment) bool {
+	_, err := strconv.Atoi(seg.key)
+	return seg.kind == keySegment && !seg.descend && err == nil
+}
+
+// sameParent returns true if parent is made of the same plain segments as the beginning of path
+func sameParent(parent []segment, path []segment) bool {
+	for i, seg := range parent {
+		other := path[i]
+		if seg.kind != keySegment || other.kind != keySegment || seg.key != other.key || seg.descend != other.descend {
+			return false
+		}
+	}
+	return true
+}
+
+// sameObject returns true if src and dst are the same map, slice or pointer
+func sameObject(src interface{}, dst interface{}) bool {
+	s, d := reflect.ValueOf(src), reflect.ValueOf(dst)
+	if !s.IsValid() || !d.IsValid() || s.Type() != d.Type() {
+		return false
+	}
+
+	switch s.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Ptr:
+		return s.Pointer() == d.Pointer()
+	}
+	return false
+}
+
+// lookupSource returns the value at from in src, which must be present for it to be moved or copied.  If it isn't, the
+// error is a *PathError for the segment that was missing, which matches ErrNotFound.
+func (a *Accessor) lookupSource(src interface{}, from string) (interface{}, error) {
+	segments, err := a.parse(from)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := followPath(src, segments, a.options)
+	if pathErr, ok := err.(*PathError); ok && isNotFound(pathErr) {
+		pathErr.Err = &notFoundError{reason: "nothing to move or copy at " + from}
+	}
+	return value, withPath(err, from)
+}
+
+// deepCopy returns a copy of obj that shares no maps, slices or pointers with it.  Unexported struct fields are copied
+// as they are, since they can't be set through reflection.
+func deepCopy(obj interface{}) interface{} {
+	if obj == nil {
+		return nil
+	}
+	return copyValue(reflect.ValueOf(obj), make(map[visit]reflect.Value)).Interface()
+}
+
+// copyValue returns a deep copy of v.  copied maps the pointers, maps and slices already copied to their copies, so
+// that shared and cyclic values are copied once.
+func copyValue(v reflect.Value, copied map[visit]reflect.Value) reflect.Value {
+	var key visit
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		// slices sharing a backing array may still differ in length
+		key = visit{ptr: v.Pointer(), t: v.Type()}
+		if c, ok := copied[key]; ok && (v.Kind() != reflect.Slice || c.Len() == v.Len()) {
+			return c
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		c := reflect.New(v.Type().Elem())
+		copied[key] = c
+		c.Elem().Set(copyValue(v.Elem(), copied))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(copyValue(v.Elem(), copied))
+		return c
+	case reflect.Map:
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		copied[key] = c
+		for _, k := range v.MapKeys() {
+			c.SetMapIndex(k, copyValue(v.MapIndex(k), copied))
+		}
+		return c
+	case reflect.Slice:
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		copied[key] = c
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(copyValue(v.Index(i), copied))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(copyValue(v.Index(i), copied))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(copyValue(v.Field(i), copied))
+			}
+		}
+		return c
+	}
+	return v
+}
@@ -0,0 +1,256 @@
+package dot
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMove_Rename(t *testing.T) {
+	doc := map[string]interface{}{
+		"user": map[string]interface{}{"name": "ann", "age": 30},
+	}
+
+	if err := Move(doc, "user.name", "profile.displayName"); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := Get(doc, "profile.displayName"); v != "ann" {
+		t.Fatal("value was not moved, got", v)
+	}
+	if _, ok := doc["user"].(map[string]interface{})["name"]; ok {
+		t.Fatal("value was not removed from its old path")
+	}
+
+	// moving a value beneath itself
+	if err := Move(doc, "profile", "profile.previous"); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := Get(doc, "profile.previous.displayName"); v != "ann" {
+		t.Fatal("value was not moved beneath itself, got", v)
+	}
+}
+
+func TestMove_RestoresOnFailure(t *testing.T) {
+	type Target struct {
+		Count int
+	}
+
+	doc := map[string]interface{}{"name": "ann", "target": &Target{}}
+	if err := Move(doc, "name", "target.Count"); err == nil {
+		t.Fatal("expected an error moving a string into an int field")
+	}
+	if doc["name"] != "ann" {
+		t.Fatal("value was not put back after a failed move")
+	}
+}
+
+func TestMoveTo(t *testing.T) {
+	type Profile struct {
+		Tags []string
+	}
+
+	src := &Profile{Tags: []string{"a"}}
+	dst := map[string]interface{}{}
+	if err := MoveTo(src, "Tags", dst, "profile.tags"); err != nil {
+		t.Fatal(err)
+	}
+	if src.Tags != nil {
+		t.Fatal("field was not cleared by the move")
+	}
+	if tags, _ := Get(dst, "profile.tags"); len(tags.([]string)) != 1 {
+		t.Fatal("value was not moved to dst, got", tags)
+	}
+}
+
+func TestCopy_IsDeep(t *testing.T) {
+	type Inner struct {
+		Values []int
+	}
+	type Outer struct {
+		Inner *Inner
+		Meta  map[string]interface{}
+	}
+
+	o := &Outer{
+		Inner: &Inner{Values: []int{1, 2}},
+		Meta:  map[string]interface{}{"list": []interface{}{"x"}},
+	}
+	doc := map[string]interface{}{"outer": o}
+
+	if err := Copy(doc, "outer", "backup.outer"); err != nil {
+		t.Fatal(err)
+	}
+
+	o.Inner.Values[0] = 100
+	o.Meta["list"].([]interface{})[0] = "y"
+	o.Meta["added"] = true
+
+	backup, _ := Get(doc, "backup.outer")
+	b := backup.(*Outer)
+	if b == o || b.Inner == o.Inner {
+		t.Fatal("pointers were aliased by the copy")
+	}
+	if b.Inner.Values[0] != 1 || b.Meta["list"].([]interface{})[0] != "x" || b.Meta["added"] != nil {
+		t.Fatal("copy changed along with the original")
+	}
+
+	// the original is still in place
+	if v, _ := Get(doc, "outer.Inner.Values.0"); v != 100 {
+		t.Fatal("original was disturbed by the copy")
+	}
+}
+
+func TestCopyTo(t *testing.T) {
+	type Node struct {
+		Next *Node
+		Name string
+	}
+
+	// cycles are copied without recursing forever
+	n := &Node{Name: "a"}
+	n.Next = n
+
+	dst := map[string]interface{}{}
+	if err := CopyTo(map[string]interface{}{"n": n}, "n", dst, "copy"); err != nil {
+		t.Fatal(err)
+	}
+	c := dst["copy"].(*Node)
+	if c == n || c.Next != c || c.Name != "a" {
+		t.Fatal("cycle was not copied faithfully")
+	}
+
+	if err := CopyTo(Node{}, "Missing", dst, "b"); err == nil {
+		t.Fatal("expected an error copying from a path that can't be read")
+	}
+}
+
+func TestMove_MissingSource(t *testing.T) {
+	doc := map[string]interface{}{"present": nil}
+	if err := Move(doc, "absent", "elsewhere"); err == nil {
+		t.Fatal("expected an error moving a missing value")
+	}
+	if _, ok := doc["elsewhere"]; ok {
+		t.Fatal("a missing value was moved")
+	}
+
+	// a nil value that is present can be moved
+	if err := Move(doc, "present", "moved"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["moved"]; !ok {
+		t.Fatal("a present nil value was not moved")
+	}
+}
+
+func TestMoveAndCopy_MissingSourceError(t *testing.T) {
+	doc := map[string]interface{}{"a": map[string]interface{}{}, "items": []interface{}{1}}
+
+	for _, from := range []string{"a.b.c", "items.3"} {
+		for name, err := range map[string]error{
+			"Move": Move(doc, from, "elsewhere"),
+			"Copy": Copy(doc, from, "elsewhere"),
+		} {
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("%s from %s did not match ErrNotFound, got %v", name, from, err)
+			}
+			var pathErr *PathError
+			if !errors.As(err, &pathErr) || pathErr.Path != from || pathErr.Segment != 1 {
+				t.Errorf("%s from %s did not return a PathError for segment 1, got %v", name, from, err)
+			}
+		}
+	}
+	if _, ok := doc["elsewhere"]; ok {
+		t.Error("a missing value was moved or copied")
+	}
+}
+
+func TestMove_WithinSlice(t *testing.T) {
+	for _, c := range []struct {
+		from, to string
+		want     []interface{}
+	}{
+		{"items.0", "items.1", []interface{}{"b", "a", "c"}},
+		{"items.0", "items.2", []interface{}{"b", "c", "a"}},
+		{"items.2", "items.0", []interface{}{"c", "a", "b"}},
+		{"items.0", "items.-1", []interface{}{"b", "c", "a"}},
+		{"items[1]", "items.1", []interface{}{"a", "b", "c"}},
+	} {
+		items := []interface{}{"a", "b", "c"}
+		doc := map[string]interface{}{"items": items}
+		if err := Move(doc, c.from, c.to); err != nil {
+			t.Fatal(c.from, c.to, err)
+		}
+		if !reflect.DeepEqual(doc["items"], c.want) {
+			t.Error("moving", c.from, "to", c.to, "gave", doc["items"], "not", c.want)
+		}
+		if items[0] != "a" || items[1] != "b" || items[2] != "c" {
+			t.Error("the original backing array was changed")
+		}
+	}
+
+	doc := map[string]interface{}{"items": []interface{}{"a", "b"}}
+	if err := Move(doc, "items.0", "items.2"); err == nil {
+		t.Error("expected an error moving beyond the end of the slice")
+	}
+	if !reflect.DeepEqual(doc["items"], []interface{}{"a", "b"}) {
+		t.Error("a failed move changed the slice, got", doc["items"])
+	}
+
+	// a slice at the root must be passed as a pointer
+	root := []string{"a", "b", "c"}
+	if err := Move(&root, "2", "0"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(root, []string{"c", "a", "b"}) {
+		t.Error("root slice was not reordered, got", root)
+	}
+}
+
+func TestMove_RestoresSliceElement(t *testing.T) {
+	doc := map[string]interface{}{
+		"items": []interface{}{"a", "b", "c"},
+		"n":     5,
+	}
+	if err := Move(doc, "items.0", "n.x"); err == nil {
+		t.Fatal("expected an error moving into a number")
+	}
+	if !reflect.DeepEqual(doc["items"], []interface{}{"a", "b", "c"}) {
+		t.Fatal("element was not put back where it was, got", doc["items"])
+	}
+
+	type Target struct {
+		Count int
+	}
+	list := &struct{ Names []string }{Names: []string{"ann", "bob"}}
+	if err := MoveTo(list, "Names.-1", &Target{}, "Count"); err == nil {
+		t.Fatal("expected an error moving a string into an int field")
+	}
+	if !reflect.DeepEqual(list.Names, []string{"ann", "bob"}) {
+		t.Fatal("element was not put back where it was, got", list.Names)
+	}
+}
+
+func TestCopy_CyclicMapAndSlice(t *testing.T) {
+	a := map[string]interface{}{"name": "a"}
+	a["loop"] = a
+	list := []interface{}{"x", nil}
+	list[1] = list
+	a["list"] = list
+	m := map[string]interface{}{"a": a}
+
+	if err := Copy(m, "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	b := m["b"].(map[string]interface{})
+	if reflect.ValueOf(b).Pointer() == reflect.ValueOf(a).Pointer() {
+		t.Fatal("map was not copied")
+	}
+	if reflect.ValueOf(b["loop"]).Pointer() != reflect.ValueOf(b).Pointer() {
+		t.Fatal("cyclic map was not copied faithfully")
+	}
+	bList := b["list"].([]interface{})
+	if &bList[0] == &list[0] || reflect.ValueOf(bList[1]).Pointer() != reflect.ValueOf(bList).Pointer() {
+		t.Fatal("cyclic slice was not copied faithfully")
+	}
+}
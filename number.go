@@ -0,0 +1,267 @@
+package dot
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type numberKind int
+
+const (
+	signedNumber numberKind = iota
+	unsignedNumber
+	floatNumber
+	bigNumber
+)
+
+// number is a numeric value of any of the types the Coerce functions accept, in the widest form of its kind
+type number struct {
+	kind numberKind
+	i    int64
+	u    uint64
+	f    float64
+	big  *big.Int
+
+	// text is set for numbers parsed from strings, json.Number or []byte
+	text bool
+}
+
+var bigIntType = reflect.TypeOf(big.Int{})
+
+// numberOf reads obj as a number.  It accepts every integer and float kind (including named types such as
+// time.Duration), pointers to any of them, big.Int and *big.Int, strings, json.Number and []byte holding decimal
+// numbers, and types whose marshaled forms are any of those (see coerceMarshaled).
+func numberOf(obj interface{}) (number, bool) {
+	if n, ok := scalarNumber(obj); ok {
+		return n, true
+	}
+
+	var n number
+	ok := coerceMarshaled(obj, func(v interface{}) bool {
+		var found bool
+		n, found = scalarNumber(v)
+		return found
+	})
+	return n, ok
+}
+
+// scalarNumber does what numberOf does, without looking at the marshaled forms of obj
+func scalarNumber(obj interface{}) (number, bool) {
+	val := reflect.ValueOf(obj)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return number{}, false
+		}
+		val = val.Elem()
+	}
+
+	if val.IsValid() && val.Type() == bigIntType {
+		v := val.Interface().(big.Int)
+		return number{kind: bigNumber, big: new(big.Int).Set(&v)}, true
+	}
+
+	switch {
+	case val.Kind() >= reflect.Int && val.Kind() <= reflect.Int64:
+		return number{kind: signedNumber, i: val.Int()}, true
+	case val.Kind() >= reflect.Uint && val.Kind() <= reflect.Uintptr:
+		return number{kind: unsignedNumber, u: val.Uint()}, true
+	case val.Kind() == reflect.Float32 || val.Kind() == reflect.Float64:
+		return number{kind: floatNumber, f: val.Float()}, true
+	case isText(val):
+		return parseNumber(textOf(val))
+	}
+	return number{}, false
+}
+
+// parseNumber reads a decimal number from s, keeping integers too large for an int64 or uint64 exact
+func parseNumber(s string) (number, bool) {
+	s = strings.TrimSpace(s)
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return number{kind: signedNumber, i: i, text: true}, true
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return number{kind: unsignedNumber, u: u, text: true}, true
+	}
+	if b, ok := new(big.Int).SetString(s, 10); ok {
+		return number{kind: bigNumber, big: b, text: true}, true
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return number{kind: floatNumber, f: f, text: true}, true
+	}
+	return number{}, false
+}
+
+// float64 returns n as a float64, which may lose precision for large integers
+func (n number) float64() float64 {
+	switch n.kind {
+	case signedNumber:
+		return float64(n.i)
+	case unsignedNumber:
+		return float64(n.u)
+	case bigNumber:
+		f, _ := new(big.Float).SetInt(n.big).Float64()
+		return f
+	}
+	return n.f
+}
+
+// string renders n in decimal
+func (n number) string() string {
+	switch n.kind {
+	case signedNumber:
+		return strconv.FormatInt(n.i, 10)
+	case unsignedNumber:
+		return strconv.FormatUint(n.u, 10)
+	case bigNumber:
+		return n.big.String()
+	}
+	return strconv.FormatFloat(n.f, 'f', -1, 64)
+}
+
+// strictInt64 returns obj as an int64, or the reason it can't be one without losing data
+func strictInt64(obj interface{}) (int64, string) {
+	n, ok := numberOf(obj)
+	if !ok {
+		return 0, "it is not a number"
+	}
+	return n.int64()
+}
+
+// int64 returns n as an int64, or the reason it can't be one without losing data
+func (n number) int64() (int64, string) {
+	switch n.kind {
+	case signedNumber:
+		return n.i, ""
+	case unsignedNumber:
+		if n.u > math.MaxInt64 {
+			return 0, "it is out of range"
+		}
+		return int64(n.u), ""
+	case bigNumber:
+		if !n.big.IsInt64() {
+			return 0, "it is out of range"
+		}
+		return n.big.Int64(), ""
+	}
+	return floatToInt(n.f)
+}
+
+// strictUint64 returns obj as a uint64, or the reason it can't be one without losing data
+func strictUint64(obj interface{}) (uint64, string) {
+	n, ok := numberOf(obj)
+	if !ok {
+		return 0, "it is not a number"
+	}
+	return n.uint64()
+}
+
+// uint64 returns n as a uint64, or the reason it can't be one without losing data
+func (n number) uint64() (uint64, string) {
+	switch n.kind {
+	case signedNumber:
+		if n.i < 0 {
+			return 0, "it is out of range"
+		}
+		return uint64(n.i), ""
+	case unsignedNumber:
+		return n.u, ""
+	case bigNumber:
+		if !n.big.IsUint64() {
+			return 0, "it is out of range"
+		}
+		return n.big.Uint64(), ""
+	}
+	return floatToUint(n.f)
+}
+
+// floatToInt returns f as an int64, or the reason it can't be one without losing data
+func floatToInt(f float64) (int64, string) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, "it is out of range"
+	}
+	if f != math.Trunc(f) {
+		return 0, "it would lose its fractional part"
+	}
+	return int64(f), ""
+}
+
+// floatToUint returns f as a uint64, or the reason it can't be one without losing data
+func floatToUint(f float64) (uint64, string) {
+	if math.IsNaN(f) || f < 0 || f >= math.MaxUint64 {
+		return 0, "it is out of range"
+	}
+	if f != math.Trunc(f) {
+		return 0, "it would lose its fractional part"
+	}
+	return uint64(f), ""
+}
+
+// CoerceInt64Strict is like CoerceInt64, except it refuses to lose data: floats must be whole numbers, and values
+// outside the range of an int64 (such as a uint64 above math.MaxInt64) are rejected rather than wrapped or truncated.
+// Strings may hold whole numbers written as floats, such as "1e3".
+func CoerceInt64Strict(obj interface{}) (int64, bool) {
+	if i, registered, ok := registeredAs[int64](obj); registered {
+		return i, ok
+	}
+
+	i, reason := strictInt64(obj)
+	return i, reason == ""
+}
+
+// GetUint64 does what Get does, except it continues through props until
+// it not only gets a non-nil value, but also gets something that can be
+// cast/coerced to a uint64 value.  Will return 0 if the property doesn't
+// exist or could not be coerced.
+func GetUint64(obj interface{}, props ...string) uint64 {
+	return std.GetUint64(obj, props...)
+}
+
+// GetUint64 does what the package-level GetUint64 does, using the Accessor's options
+func (a *Accessor) GetUint64(obj interface{}, props ...string) uint64 {
+	v, _, _ := getFirst(a, obj, props, coerceWith(CoerceUint64))
+	return v
+}
+
+// CoerceUint64 will make a best-effort to convert the provided argument to a uint64, accepting what CoerceInt64 does.
+// Negative values can't be coerced, and floats are truncated.
+func CoerceUint64(obj interface{}) (uint64, bool) {
+	if u, registered, ok := registeredAs[uint64](obj); registered {
+		return u, ok
+	}
+
+	n, ok := numberOf(obj)
+	if !ok {
+		return 0, false
+	}
+
+	if n.kind == floatNumber {
+		// strings must hold integers
+		if n.text || math.IsNaN(n.f) || n.f <= -1 || n.f >= math.MaxUint64 {
+			return 0, false
+		}
+		return uint64(n.f), true
+	}
+
+	u, reason := n.uint64()
+	return u, reason == ""
+}
+
+// CoerceUint64Strict is like CoerceUint64, except floats must be whole numbers, as with CoerceInt64Strict
+func CoerceUint64Strict(obj interface{}) (uint64, bool) {
+	if u, registered, ok := registeredAs[uint64](obj); registered {
+		return u, ok
+	}
+
+	u, reason := strictUint64(obj)
+	return u, reason == ""
+}
+
+// ParseInt parses s as an integer, detecting its base from its prefix as Go does: "0x" for hexadecimal, "0o" or a
+// leading "0" for octal, "0b" for binary, and decimal otherwise.  Underscores may separate digits as they may in Go
+// literals, and surrounding whitespace is ignored.
+func ParseInt(s string) (int64, error) {
+	return strconv.ParseInt(strings.TrimSpace(s), 0, 64)
+}
@@ -0,0 +1,169 @@
+package dot
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestCoerceInt64_NumberTypes(t *testing.T) {
+	seven := 7
+	bigSeven := big.NewInt(7)
+
+	for _, value := range []interface{}{
+		int8(7), int16(7), uint(7), uint8(7), uint16(7), uint32(7), uint64(7),
+		json.Number("7"), &seven, *bigSeven, bigSeven, "7", []byte(" 7 "),
+	} {
+		if i, ok := CoerceInt64(value); !ok || i != 7 {
+			t.Errorf("did not coerce %T %v to 7, got %d", value, value, i)
+		}
+		if i, ok := CoerceInt64Strict(value); !ok || i != 7 {
+			t.Errorf("did not strictly coerce %T %v to 7, got %d", value, value, i)
+		}
+	}
+
+	var nilInt *int
+	for _, value := range []interface{}{nil, nilInt, "7.5", json.Number("abc"), uint64(math.MaxUint64), true} {
+		if _, ok := CoerceInt64(value); ok {
+			t.Errorf("coerced %T %v", value, value)
+		}
+	}
+}
+
+func TestCoerceInt64Strict(t *testing.T) {
+	if i, ok := CoerceInt64(7.9); !ok || i != 7 {
+		t.Error("CoerceInt64 did not truncate 7.9, got", i)
+	}
+
+	for _, value := range []interface{}{
+		1.5, float32(-0.5), json.Number("1.5"), uint64(math.MaxInt64) + 1, math.Inf(1), math.NaN(), "abc",
+	} {
+		if _, ok := CoerceInt64Strict(value); ok {
+			t.Errorf("strictly coerced %T %v", value, value)
+		}
+	}
+
+	for _, value := range []interface{}{3.0, json.Number("3e0"), "3.0"} {
+		if i, ok := CoerceInt64Strict(value); !ok || i != 3 {
+			t.Errorf("did not strictly coerce %T %v to 3, got %d", value, value, i)
+		}
+	}
+}
+
+func TestCoerceUint64(t *testing.T) {
+	if u, ok := CoerceUint64(uint64(math.MaxUint64)); !ok || u != math.MaxUint64 {
+		t.Error("did not coerce the largest uint64, got", u)
+	}
+	if u, ok := CoerceUint64("18446744073709551615"); !ok || u != math.MaxUint64 {
+		t.Error("did not coerce the largest uint64 from a string, got", u)
+	}
+	if u, ok := CoerceUint64(2.5); !ok || u != 2 {
+		t.Error("did not truncate 2.5, got", u)
+	}
+
+	for _, value := range []interface{}{-1, int8(-1), -1.5, "-1", "18446744073709551616", "abc"} {
+		if _, ok := CoerceUint64(value); ok {
+			t.Errorf("coerced %T %v", value, value)
+		}
+	}
+
+	if _, ok := CoerceUint64Strict(2.5); ok {
+		t.Error("strictly coerced 2.5")
+	}
+	if u, ok := CoerceUint64Strict(json.Number("42")); !ok || u != 42 {
+		t.Error("did not strictly coerce 42, got", u)
+	}
+}
+
+func TestGetUint64(t *testing.T) {
+	data := map[string]interface{}{
+		"negative": -5,
+		"count":    uint16(12),
+	}
+
+	if GetUint64(data, "negative") != 0 {
+		t.Error("negative value was not 0")
+	}
+	if GetUint64(data, "missing", "negative", "count") != 12 {
+		t.Error("fallback did not fall back to the correct value")
+	}
+}
+
+func TestGet_UseNumber(t *testing.T) {
+	var data interface{}
+	decoder := json.NewDecoder(strings.NewReader(`{"id": 9007199254740993, "ratio": 0.25, "big": 123456789012345678901234567890}`))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		t.Fatal(err)
+	}
+
+	if GetInt64(data, "id") != 9007199254740993 {
+		t.Error("id lost precision, got", GetInt64(data, "id"))
+	}
+	if GetFloat64(data, "ratio") != 0.25 {
+		t.Error("ratio was not 0.25")
+	}
+	if GetString(data, "big") != "123456789012345678901234567890" {
+		t.Error("big was not kept exact, got", GetString(data, "big"))
+	}
+	if GetInt64(data, "big") != 0 {
+		t.Error("big should not fit an int64")
+	}
+}
+
+func TestCoerceString_Numbers(t *testing.T) {
+	for value, want := range map[interface{}]string{
+		json.Number("12.50"):   "12.50",
+		uint64(math.MaxUint64): "18446744073709551615",
+		int8(-3):               "-3",
+		2.5:                    "2.5",
+	} {
+		if got, _ := CoerceString(value); got != want {
+			t.Errorf("CoerceString(%T %v) was %q, not %q", value, value, got, want)
+		}
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	for s, want := range map[string]int64{
+		"0x1f":   31,
+		"0X1F":   31,
+		"0o17":   15,
+		"017":    15,
+		"0b101":  5,
+		" 42 ":   42,
+		"-0x10":  -16,
+		"1_000":  1000,
+		"0x_1_0": 16,
+	} {
+		got, err := ParseInt(s)
+		if err != nil || got != want {
+			t.Errorf("ParseInt(%q) was %d, %v, not %d", s, got, err, want)
+		}
+	}
+
+	for _, s := range []string{"", "0x", "09", "12a", "1__0"} {
+		if _, err := ParseInt(s); err == nil {
+			t.Errorf("ParseInt(%q) should fail", s)
+		}
+	}
+}
+
+func TestCoerceInt64_RejectsFractionalText(t *testing.T) {
+	for _, value := range []interface{}{"2.9", "0.5", json.Number("2.0"), []byte("1e3")} {
+		if _, ok := CoerceInt64(value); ok {
+			t.Errorf("coerced %T %v", value, value)
+		}
+		if _, ok := CoerceUint64(value); ok {
+			t.Errorf("coerced %T %v to a uint64", value, value)
+		}
+	}
+
+	// text that isn't an integer is passed over in favor of the next prop
+	data := map[string]interface{}{"a": "2.9", "b": 7}
+	if GetInt64(data, "a", "b") != 7 {
+		t.Error("did not fall back past fractional text, got", GetInt64(data, "a", "b"))
+	}
+}
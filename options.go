@@ -0,0 +1,99 @@
+package dot
+
+import "errors"
+
+// Option alters the behavior of the operations that accept it
+type Option func(*options)
+
+type options struct {
+	growSlices bool
+	separator  string
+	jsonTags   bool
+	caseMode   caseMode
+	convert    bool
+}
+
+// caseMode is how keys in paths are matched against map keys and struct fields
+type caseMode int
+
+const (
+	// caseDefault matches map keys exactly, and struct fields with the first letter of the key upper-cased
+	caseDefault caseMode = iota
+
+	// caseInsensitive matches map keys and struct fields ignoring case, preferring exact matches
+	caseInsensitive
+
+	// caseStrict matches map keys and struct fields exactly
+	caseStrict
+)
+
+// GrowSlices allows Set to write to an index beyond the end of a slice.  The slice is padded with zero values up to the
+// index being written.  Without it, writing past the end of a slice results in an *IndexOutOfRangeError.
+func GrowSlices() Option {
+	return func(o *options) {
+		o.growSlices = true
+	}
+}
+
+// WithSeparator makes paths separate keys with sep rather than a period, which is useful when keys are full of periods
+// (e.g. "app.kubernetes.io/name").  Recursive descent is then written as sep twice, and keys containing sep must be
+// escaped or quoted, just as keys containing periods otherwise are.  Separators may not contain brackets, quotes,
+// backslashes, whitespace, or the characters *, + and ?.
+func WithSeparator(sep string) Option {
+	return func(o *options) {
+		o.separator = sep
+	}
+}
+
+// WithJSONTags makes struct fields addressable by the names in their json tags, so a field tagged `json:"user_id"` is
+// the key user_id.  Fields without a json name keep their Go name, and fields tagged `json:"-"` can't be addressed and
+// aren't listed by Keys.  Keys reports json names in place of Go names, but Go names continue to work in paths.
+func WithJSONTags() Option {
+	return func(o *options) {
+		o.jsonTags = true
+	}
+}
+
+// WithCaseInsensitive matches keys against map keys and struct fields ignoring case, so "username" finds UserName or a
+// map key "USERNAME".  An exact match always wins.  Otherwise, if a key matches more than one map key or field, the
+// lookup fails with an *AmbiguousKeyError rather than picking one.  When Set writes to a map, it reuses an existing key
+// that matches rather than adding a key that differs only in case.
+func WithCaseInsensitive() Option {
+	return func(o *options) {
+		o.caseMode = caseInsensitive
+	}
+}
+
+// WithStrictCase matches keys exactly.  By default, the first letter of a key is upper-cased to find a struct field
+// (so "name" finds Name); in strict mode it is not.
+func WithStrictCase() Option {
+	return func(o *options) {
+		o.caseMode = caseStrict
+	}
+}
+
+// WithConversion makes Set convert values that can't be assigned to their destination as they are, so a field of type
+// int can be set from "8080" or from the float64 8080 that encoding/json produces.  Strings, numbers and bools are
+// converted between one another, and pointer fields are allocated to hold the converted value.  A conversion that
+// would lose data, such as 1.5 or "300" into an int8, fails with a *ConversionError.
+func WithConversion() Option {
+	return func(o *options) {
+		o.convert = true
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{separator: "."}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// validate returns an error if the options can't be used, such as when the separator is unusable
+func (o *options) validate() error {
+	if !validSeparator(o.separator) {
+		return errors.New("invalid separator \"" + o.separator + "\"")
+	}
+	return nil
+}
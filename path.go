@@ -0,0 +1,292 @@
+package dot
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type segmentKind int
+
+const (
+	// keySegment addresses a single map key, struct field or slice index
+	keySegment segmentKind = iota
+
+	// wildcardSegment fans out across every child of a node ("*" or "[*]")
+	wildcardSegment
+
+	// filterSegment fans out across the children of a node matched by a filter ("[?expression]")
+	filterSegment
+
+	// appendSegment adds an element to the end of a slice ("+" or "[]")
+	appendSegment
+)
+
+// segment is a single step of a path
+type segment struct {
+	kind segmentKind
+	key  string
+
+	// filter is set for filterSegment segments
+	filter filter
+
+	// descend is set when the segment was preceded by "..", and may match at any depth
+	descend bool
+
+	// pointer is set for segments parsed from a JSON Pointer, which index slices more strictly
+	pointer bool
+
+	// index is the position of the segment within its path, for reporting errors
+	index int
+}
+
+// fansOut returns true if the segment may address more than one child of a node
+func (s segment) fansOut() bool {
+	return s.kind == wildcardSegment || s.kind == filterSegment
+}
+
+// matches returns true if the segment follows child, which was reached by fanning out
+func (s segment) matches(child interface{}, o *options) bool {
+	return s.filter == nil || s.filter.match(child, o)
+}
+
+// parsePath breaks prop into segments.  The grammar is:
+//
+//   - keys are separated by periods, and whitespace around them is ignored
+//   - a backslash makes the next character part of the key, so "a\.b" is the single key "a.b"
+//   - ["key"] or ['key'] addresses a key exactly as quoted, and may contain \\, \", \', \n, \r and \t escapes
+//   - [0] or [-1] addresses a slice index, just like the keys 0 and -1
+//   - * or [*] is a wildcard, and [?expression] is a filter
+//   - + or [] appends to a slice
+//   - .. before a segment lets it match at any depth
+//
+// Periods are the default separator, and sep takes their place in all of the above.  The empty string is the path of
+// the single empty key.
+func parsePath(prop string, sep string) ([]segment, error) {
+	var segments []segment
+	descend := false
+
+	for i := 0; ; {
+		start := i
+
+		// find the bare key, up to the next unescaped period or bracket
+		for ; i < len(prop) && !strings.HasPrefix(prop[i:], sep) && prop[i] != '['; i++ {
+			if prop[i] == '\\' && i+1 < len(prop) {
+				i++
+			}
+		}
+		bare, escaped := unescapeBare(strings.TrimSpace(prop[start:i]))
+
+		hasBrackets := i < len(prop) && prop[i] == '['
+		descentFollows := strings.HasPrefix(prop[i:], sep+sep)
+		if bare != "" || escaped {
+			segments = append(segments, bareSegment(bare, escaped, descend))
+			descend = false
+		} else if !hasBrackets && !(descentFollows && i == start) {
+			segments = append(segments, segment{kind: keySegment, descend: descend})
+			descend = false
+		}
+
+		// read any bracketed segments
+		for i < len(prop) && prop[i] == '[' {
+			seg, end, err := parseBracket(prop, i, sep)
+			if err != nil {
+				return nil, err
+			}
+			seg.descend = descend
+			descend = false
+			segments = append(segments, seg)
+			i = end + 1
+		}
+
+		if i >= len(prop) {
+			break
+		}
+
+		if !strings.HasPrefix(prop[i:], sep) {
+			return nil, errors.New("unexpected " + string(prop[i]) + " at position " + strconv.Itoa(i) + " in " + prop)
+		}
+		i += len(sep)
+
+		if strings.HasPrefix(prop[i:], sep) {
+			descend = true
+			i += len(sep)
+
+			if i >= len(prop) {
+				return nil, errors.New("path may not end in recursive descent: " + prop)
+			}
+		}
+	}
+
+	for i := range segments {
+		segments[i].index = i
+	}
+	return segments, nil
+}
+
+// unescapeBare removes the backslashes from a bare key, reporting whether there were any
+func unescapeBare(raw string) (string, bool) {
+	if !strings.ContainsRune(raw, '\\') {
+		return raw, false
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '\\' && i+1 < len(raw) {
+			i++
+		}
+		sb.WriteByte(raw[i])
+	}
+	return sb.String(), true
+}
+
+// bareSegment interprets a key that wasn't in brackets
+func bareSegment(key string, escaped bool, descend bool) segment {
+	if !escaped {
+		switch key {
+		case "*":
+			return segment{kind: wildcardSegment, descend: descend}
+		case "+":
+			return segment{kind: appendSegment, descend: descend}
+		}
+	}
+	return segment{kind: keySegment, key: key, descend: descend}
+}
+
+// parseBracket parses the bracketed segment opened at start, returning it along with the position of its closing ]
+func parseBracket(prop string, start int, sep string) (segment, int, error) {
+	i := start + 1
+	if i >= len(prop) {
+		return segment{}, 0, errors.New("missing ] in " + prop)
+	}
+
+	switch prop[i] {
+	case '"', '\'':
+		key, end, err := parseQuoted(prop, i)
+		if err != nil {
+			return segment{}, 0, err
+		}
+		if end+1 >= len(prop) || prop[end+1] != ']' {
+			return segment{}, 0, errors.New("expected ] after quoted key in " + prop)
+		}
+		return segment{kind: keySegment, key: key}, end + 1, nil
+	case '?':
+		end := closingBracket(prop, start)
+		if end < 0 {
+			return segment{}, 0, errors.New("missing ] for filter in " + prop)
+		}
+
+		f, err := parseFilter(prop[i+1:end], sep)
+		if err != nil {
+			return segment{}, 0, err
+		}
+		return segment{kind: filterSegment, filter: f}, end, nil
+	}
+
+	end := strings.IndexByte(prop[i:], ']')
+	if end < 0 {
+		return segment{}, 0, errors.New("missing ] in " + prop)
+	}
+	end += i
+
+	content := strings.TrimSpace(prop[i:end])
+	switch content {
+	case "":
+		return segment{kind: appendSegment}, end, nil
+	case "*":
+		return segment{kind: wildcardSegment}, end, nil
+	}
+
+	if _, err := strconv.Atoi(content); err != nil {
+		return segment{}, 0, errors.New("bracket must hold a quoted key, an index, * or a filter, not " + content)
+	}
+	return segment{kind: keySegment, key: content}, end, nil
+}
+
+// parseQuoted reads the quoted string opened at start, returning its unescaped content and the position of the
+// closing quote
+func parseQuoted(prop string, start int) (string, int, error) {
+	quote := prop[start]
+
+	var sb strings.Builder
+	for i := start + 1; i < len(prop); i++ {
+		c := prop[i]
+		if c == quote {
+			return sb.String(), i, nil
+		}
+
+		if c == '\\' && i+1 < len(prop) {
+			i++
+			switch prop[i] {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			default:
+				c = prop[i]
+			}
+		}
+		sb.WriteByte(c)
+	}
+	return "", 0, errors.New("unterminated quoted key in " + prop)
+}
+
+// closingBracket returns the position of the ] closing the [ at start, skipping over nested brackets and ignoring any
+// within quotes, or -1
+func closingBracket(prop string, start int) int {
+	var quote byte
+	depth := 0
+	for i := start + 1; i < len(prop); i++ {
+		c := prop[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// formatKey renders key as a path segment, quoting it in brackets if it couldn't otherwise be read back as the same key
+// using the separator sep
+func formatKey(key string, sep string) string {
+	if key != "" && key != "*" && key != "+" && strings.TrimSpace(key) == key &&
+		!strings.ContainsAny(key, "[]\\\"'") && !strings.Contains(key, sep) {
+		return key
+	}
+
+	escaper := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\r", "\\r", "\t", "\\t")
+	return "[\"" + escaper.Replace(key) + "\"]"
+}
+
+// joinPath appends key to the path parent, quoting key if necessary
+func joinPath(parent string, key string, sep string) string {
+	return joinFormatted(parent, formatKey(key, sep), sep)
+}
+
+// joinFormatted appends a segment already rendered by formatKey to the path parent
+func joinFormatted(parent string, formatted string, sep string) string {
+	if len(parent) == 0 || formatted[0] == '[' {
+		return parent + formatted
+	}
+	return parent + sep + formatted
+}
+
+// validSeparator returns true if sep can separate keys without being confused with the rest of the grammar
+func validSeparator(sep string) bool {
+	return sep != "" && !strings.ContainsAny(sep, "[]\\\"' \t\n\r*+?")
+}
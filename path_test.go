@@ -0,0 +1,154 @@
+package dot
+
+import "testing"
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		prop string
+		keys []string
+	}{
+		{"a.b.c", []string{"a", "b", "c"}},
+		{"", []string{""}},
+		{"a\\.b", []string{"a.b"}},
+		{"a\\\\b", []string{"a\\b"}},
+		{"a[\"key.with.dots\"]", []string{"a", "key.with.dots"}},
+		{"a['x'].y", []string{"a", "x", "y"}},
+		{"a[0][-1]", []string{"a", "0", "-1"}},
+		{"a.[\"b\"]", []string{"a", "b"}},
+		{"[\"\"]", []string{""}},
+		{"[\"a\\\"b\\\\c\\nd\"]", []string{"a\"b\\c\nd"}},
+		{"[\"\a\"]", []string{"\a"}},
+		{"\\*", []string{"*"}},
+		{" a . b ", []string{"a", "b"}},
+	}
+
+	for _, test := range tests {
+		segments, err := parsePath(test.prop, ".")
+		if err != nil {
+			t.Error(test.prop + ": " + err.Error())
+			continue
+		}
+
+		if len(segments) != len(test.keys) {
+			t.Error(test.prop + ": wrong number of segments")
+			continue
+		}
+		for i, seg := range segments {
+			if seg.kind != keySegment || seg.key != test.keys[i] {
+				t.Error(test.prop + ": unexpected segment " + seg.key)
+			}
+		}
+	}
+}
+
+func TestParsePath_SpecialSegments(t *testing.T) {
+	segments, err := parsePath("a.*.b[*]..c[]", ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 6 {
+		t.Fatal("wrong number of segments")
+	}
+	if segments[1].kind != wildcardSegment || segments[3].kind != wildcardSegment {
+		t.Fatal("* and [*] were not wildcards")
+	}
+	if segments[4].key != "c" || !segments[4].descend {
+		t.Fatal("..c was not a recursive descent")
+	}
+	if segments[5].kind != appendSegment {
+		t.Fatal("[] was not an append")
+	}
+
+	segments, err = parsePath("tags.+", ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if segments[1].kind != appendSegment {
+		t.Fatal("+ was not an append")
+	}
+
+	// quoting and escaping make special keys literal
+	segments, err = parsePath("[\"*\"].\\+", ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if segments[0].kind != keySegment || segments[0].key != "*" || segments[1].kind != keySegment {
+		t.Fatal("quoted and escaped segments were not literal keys")
+	}
+}
+
+func TestParsePath_Errors(t *testing.T) {
+	for _, prop := range []string{
+		"a[\"b\"",
+		"a[\"b",
+		"a[b]",
+		"a[0",
+		"a[0]b",
+		"a..",
+	} {
+		if _, err := parsePath(prop, "."); err == nil {
+			t.Error("did not get an error for " + prop)
+		}
+	}
+}
+
+func TestFormatKey_RoundTrip(t *testing.T) {
+	for _, key := range []string{"plain", "example.com", "", "*", "+", " padded ", "a[0]", "back\\slash", "q\"uote",
+		"it's", "bell\a", "new\nline"} {
+		segments, err := parsePath(formatKey(key, "."), ".")
+		if err != nil {
+			t.Error(key + ": " + err.Error())
+			continue
+		}
+		if len(segments) != 1 || segments[0].kind != keySegment || segments[0].key != key {
+			t.Error("key did not round trip: " + key)
+		}
+	}
+
+	if joinPath("a", "b.c", ".") != "a[\"b.c\"]" || joinPath("", "b", ".") != "b" || joinPath("a", "b", ".") != "a.b" {
+		t.Fatal("joinPath did not join as expected")
+	}
+}
+
+func TestGetSet_QuotedKeys(t *testing.T) {
+	data := map[string]interface{}{}
+
+	if err := Set(data, "sites[\"https://example.com/a.b\"].hits", 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(data, "sites['example.org'].hits", 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(data, "odd[\"\a\"][\"\\\\\"][\"\"]", "deep"); err != nil {
+		t.Fatal(err)
+	}
+
+	sites := data["sites"].(map[string]interface{})
+	if sites["https://example.com/a.b"].(map[string]interface{})["hits"] != 4 {
+		t.Fatal("quoted URL key was not set")
+	}
+
+	if GetInt64(data, "sites[\"example.org\"].hits") != 2 {
+		t.Fatal("could not get a quoted key")
+	}
+
+	if GetString(data, "odd[\"\a\"][\"\\\\\"][\"\"]") != "deep" {
+		t.Fatal("could not get keys made of a bell, a backslash and nothing")
+	}
+
+	// keys come back quoted, so they can be used to get the value again
+	for _, k := range KeysRecursiveLeaves(data) {
+		if v, err := Get(data, k); err != nil || v == nil {
+			t.Fatal("could not get the value at key " + k)
+		}
+	}
+
+	// and so Extend can copy them
+	to := map[string]interface{}{}
+	if err := Extend(to, data); err != nil {
+		t.Fatal(err)
+	}
+	if GetInt64(to, "sites[\"https://example.com/a.b\"].hits") != 4 {
+		t.Fatal("Extend did not copy a key containing periods")
+	}
+}
@@ -0,0 +1,151 @@
+package dot
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// pointerAccessor resolves JSON Pointers, whose reference tokens must match keys and struct fields exactly
+var pointerAccessor = New(WithStrictCase())
+
+// GetPointer returns the value in obj at the location given by an RFC 6901 JSON Pointer, such as "/a/b~1c/0".  The
+// empty pointer refers to obj itself.  Reference tokens match map keys and struct fields exactly, so "/name" does not
+// find a field named Name.  Unlike dot notation, slice indices must be written without signs or leading zeros, and "-"
+// (the element after the last) is always out of range.  Options such as WithJSONTags apply on top of the exact
+// matching, so "/user_id" finds a field tagged `json:"user_id"`.
+func GetPointer(obj interface{}, pointer string, opts ...Option) (interface{}, error) {
+	segments, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := pointerAccessor.with(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if obj == nil {
+		return nil, nil
+	}
+
+	v, err := getPath(obj, segments, a.options)
+	return v, withPath(err, pointer)
+}
+
+// SetPointer applies value at the location given by an RFC 6901 JSON Pointer, such as "/a/b~1c/0", allocating missing
+// nodes just as Set does.  The token "-" appends to a slice.  Options apply as they do for GetPointer.
+func SetPointer(obj interface{}, pointer string, value interface{}, opts ...Option) error {
+	segments, err := parsePointer(pointer)
+	if err != nil {
+		return err
+	}
+
+	if len(segments) == 0 {
+		return errors.New("the empty pointer refers to obj itself, which can't be replaced")
+	}
+	a, err := pointerAccessor.with(opts)
+	if err != nil {
+		return err
+	}
+	return withPath(a.setPath(obj, segments, value), pointer)
+}
+
+// CompilePointer parses an RFC 6901 JSON Pointer into a Path, which behaves as GetPointer and SetPointer do with the
+// same options
+func CompilePointer(pointer string, opts ...Option) (*Path, error) {
+	segments, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := pointerAccessor.with(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Path{prop: pointer, segments: segments, accessor: a}, nil
+}
+
+// parsePointer breaks a JSON Pointer into segments, one for each reference token
+func parsePointer(pointer string) ([]segment, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if pointer[0] != '/' {
+		return nil, errors.New("JSON Pointer must be empty or start with '/': " + pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	segments := make([]segment, len(tokens))
+	for i, token := range tokens {
+		key, err := unescapePointerToken(token)
+		if err != nil {
+			return nil, err
+		}
+		segments[i] = segment{kind: keySegment, key: key, pointer: true, index: i}
+	}
+	return segments, nil
+}
+
+// unescapePointerToken replaces ~1 with / and then ~0 with ~, rejecting any other use of ~
+func unescapePointerToken(token string) (string, error) {
+	if !strings.ContainsRune(token, '~') {
+		return token, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(token); i++ {
+		if token[i] != '~' {
+			sb.WriteByte(token[i])
+			continue
+		}
+
+		if i+1 >= len(token) || (token[i+1] != '0' && token[i+1] != '1') {
+			return "", errors.New("JSON Pointer token has an invalid ~ escape: " + token)
+		}
+
+		if token[i+1] == '0' {
+			sb.WriteByte('~')
+		} else {
+			sb.WriteByte('/')
+		}
+		i++
+	}
+	return sb.String(), nil
+}
+
+// checkPointerIndexOf validates seg as an index if obj is a slice or array (or a pointer to one)
+func checkPointerIndexOf(obj interface{}, seg segment) error {
+	val := reflect.ValueOf(obj)
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return nil
+	}
+	return checkPointerIndex(seg, val.Len(), false)
+}
+
+// checkPointerIndex validates seg as an index into a slice of the given length, when seg came from a JSON Pointer.
+// JSON Pointer indices are digits without leading zeros, or "-" for the element after the last, which is only
+// meaningful when appending.
+func checkPointerIndex(seg segment, length int, appending bool) error {
+	if !seg.pointer {
+		return nil
+	}
+
+	if seg.key == "-" {
+		if appending {
+			return nil
+		}
+		return &IndexOutOfRangeError{Index: length, Length: length}
+	}
+
+	key := seg.key
+	if key == "" || (len(key) > 1 && key[0] == '0') || strings.TrimLeft(key, "0123456789") != "" {
+		return typeMismatch("JSON Pointer array index must be digits without leading zeros, not " + key)
+	}
+	return nil
+}
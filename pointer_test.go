@@ -0,0 +1,175 @@
+package dot
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGetPointer_RFC6901Examples(t *testing.T) {
+	var doc map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"foo": ["bar", "baz"],
+		"": 0,
+		"a/b": 1,
+		"c%d": 2,
+		"e^f": 3,
+		"g|h": 4,
+		"i\\j": 5,
+		"k\"l": 6,
+		" ": 7,
+		"m~n": 8
+	}`), &doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	whole, err := GetPointer(doc, "")
+	if err != nil || whole.(map[string]interface{})["m~n"] != 8.0 {
+		t.Fatal("the empty pointer did not refer to the whole document")
+	}
+
+	if v, err := GetPointer(doc, "/foo/0"); err != nil || v != "bar" {
+		t.Fatal("/foo/0 was not bar")
+	}
+
+	expected := map[string]float64{
+		"/":     0,
+		"/a~1b": 1,
+		"/c%d":  2,
+		"/e^f":  3,
+		"/g|h":  4,
+		"/i\\j": 5,
+		"/k\"l": 6,
+		"/ ":    7,
+		"/m~0n": 8,
+	}
+	for pointer, value := range expected {
+		v, err := GetPointer(doc, pointer)
+		if err != nil {
+			t.Error(pointer + ": " + err.Error())
+			continue
+		}
+		if v != value {
+			t.Error(pointer + " did not refer to the expected value")
+		}
+	}
+}
+
+func TestGetPointer_Errors(t *testing.T) {
+	doc := map[string]interface{}{
+		"foo": []interface{}{"bar", "baz"},
+		"-1":  "a map key, not an index",
+	}
+
+	for _, pointer := range []string{"foo", "/foo/-1", "/foo/01", "/foo/-", "/foo/2", "/a~2b", "/a~"} {
+		if _, err := GetPointer(doc, pointer); err == nil {
+			t.Error("did not get an error for " + pointer)
+		}
+	}
+
+	// tokens that aren't valid indices are still valid map keys
+	if v, err := GetPointer(doc, "/-1"); err != nil || v == nil {
+		t.Fatal("/-1 did not refer to the map key -1")
+	}
+}
+
+func TestSetPointer(t *testing.T) {
+	type Patch struct {
+		Labels map[string]string
+		Ops    []string
+	}
+
+	p := Patch{}
+	if err := SetPointer(&p, "/Labels/app.kubernetes.io~1name", "web"); err != nil {
+		t.Fatal(err)
+	}
+	if p.Labels["app.kubernetes.io/name"] != "web" {
+		t.Fatal("escaped pointer key with periods was not set")
+	}
+
+	if err := SetPointer(&p, "/Ops/-", "add"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetPointer(&p, "/Ops/-", "remove"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetPointer(&p, "/Ops/0", "replace"); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Ops) != 2 || p.Ops[0] != "replace" || p.Ops[1] != "remove" {
+		t.Fatal("/Ops/- did not append")
+	}
+
+	if err := SetPointer(&p, "/Ops/-1", "x"); err == nil {
+		t.Fatal("did not get an error for a negative pointer index")
+	}
+
+	if err := SetPointer(&p, "", "x"); err == nil {
+		t.Fatal("did not get an error replacing the root")
+	}
+
+	compiled, err := CompilePointer("/Ops/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := compiled.Get(p); err != nil || v != "remove" {
+		t.Fatal("compiled pointer did not get /Ops/1")
+	}
+	if compiled.String() != "/Ops/1" {
+		t.Fatal("compiled pointer String was not the pointer")
+	}
+}
+
+func TestPointer_MatchesFieldsExactly(t *testing.T) {
+	type User struct {
+		Name string
+	}
+	u := &User{Name: "ann"}
+
+	if v, err := GetPointer(u, "/Name"); err != nil || v != "ann" {
+		t.Fatal("/Name was not ann:", v, err)
+	}
+	if _, err := GetPointer(u, "/name"); err == nil {
+		t.Error("/name found the field Name")
+	}
+	if err := SetPointer(u, "/name", "bob"); err == nil || u.Name != "ann" {
+		t.Error("/name set the field Name")
+	}
+
+	p, err := CompilePointer("/name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Get(u); err == nil {
+		t.Error("compiled /name found the field Name")
+	}
+}
+
+func TestPointer_JSONTags(t *testing.T) {
+	type User struct {
+		ID   int    `json:"user_id"`
+		Name string `json:"name"`
+	}
+	u := &User{ID: 7}
+
+	if v, err := GetPointer(u, "/user_id", WithJSONTags()); err != nil || v != 7 {
+		t.Fatal("/user_id was not 7:", v, err)
+	}
+	if _, err := GetPointer(u, "/user_id"); err == nil {
+		t.Error("/user_id found a tagged field without WithJSONTags")
+	}
+	if err := SetPointer(u, "/name", "ann", WithJSONTags()); err != nil || u.Name != "ann" {
+		t.Error("/name was not set through its tag:", err)
+	}
+
+	p, err := CompilePointer("/user_id", WithJSONTags())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Set(u, 8); err != nil || u.ID != 8 {
+		t.Error("compiled /user_id did not set the tagged field:", err)
+	}
+	if v, err := p.Get(u); err != nil || v != 8 {
+		t.Error("compiled /user_id was not 8:", v, err)
+	}
+}
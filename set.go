@@ -1,137 +1,320 @@
 package dot
 
 import (
-	"encoding/json"
 	"errors"
-	"github.com/oleiade/reflections"
 	"reflect"
 	"strings"
 )
 
 // Set will apply the value specified by the value argument at the "position"/attribute in the provided obj argument.
-// It will allocate map[string]interface{} for any missing nodes in the "tree" generated by addressing. Returns an error
-// if it cannot apply the provided value for any reason.
-func Set(obj interface{}, prop string, value interface{}) error {
+// It will allocate map[string]interface{} for any missing nodes in the "tree" generated by addressing, or
+// []interface{} when the missing node is followed by an append segment ("+" or "[]").  Nil maps are made, and nil
+// pointers (to structs, slices, maps or other pointers) are pointed at a new zero value, so Set(&user,
+// "Address.City", "x") allocates an Address if user.Address is nil.  Numeric segments write into
+// existing slice elements, and append segments add an element to the end of the slice.  Returns an error if it cannot
+// apply the provided value for any reason.
+func Set(obj interface{}, prop string, value interface{}, opts ...Option) error {
+	return std.Set(obj, prop, value, opts...)
+}
+
+// Set does what the package-level Set does, using the Accessor's options along with any given here
+func (a *Accessor) Set(obj interface{}, prop string, value interface{}, opts ...Option) error {
+	a, err := a.with(opts)
+	if err != nil {
+		return err
+	}
 	if obj == nil {
-		return errors.New("obj may not be nil for dot.Set")
+		return notAddressable("obj may not be nil for dot.Set")
 	}
 
+	segments, err := a.parseWritable(prop, "set")
+	if err != nil {
+		return err
+	}
+	return withPath(a.setPath(obj, segments, value), prop)
+}
+
+// parseWritable parses prop for an operation that writes to obj, such as dot-set, which is named by op in errors
+func (a *Accessor) parseWritable(prop string, op string) ([]segment, error) {
 	// trim outer spaces from property
 	prop = strings.TrimSpace(prop)
 
+	if err := a.checkWritable(prop, op); err != nil {
+		return nil, err
+	}
+	return a.parse(prop)
+}
+
+// checkWritable returns an error if prop parses, but is still not a path that an operation writing to obj (named by op
+// in errors) may use, because it begins or ends with the separator
+func (a *Accessor) checkWritable(prop string, op string) error {
+	prop = strings.TrimSpace(prop)
+
 	// validate obvious pathing errors
+	sep := a.options.separator
 	if len(prop) > 0 {
-		if prop[0] == '.' {
-			return errors.New("dot-set property may not start with '.'")
+		if strings.HasPrefix(prop, sep) {
+			return errors.New("dot-" + op + " property may not start with '" + sep + "'")
 		}
 
-		if prop[len(prop)-1] == '.' {
-			return errors.New("dot-set property may not end in '.'")
+		if strings.HasSuffix(prop, sep) {
+			return errors.New("dot-" + op + " property may not end in '" + sep + "'")
 		}
 	}
+	return nil
+}
 
-	// get the array access
-	// TODO: improve escape mechanism for \.
-	arr := strings.Split(strings.ReplaceAll(prop, "\\.", "\a"), ".")
+// setPath applies value at the location given by segments within obj
+func (a *Accessor) setPath(obj interface{}, segments []segment, value interface{}) error {
+	if obj == nil {
+		return notAddressable("obj may not be nil for dot.Set")
+	}
 
-	// adjust a struct to be a map TODO: improve for performance concerns (and code cleanliness)
-	effReflect := reflect.TypeOf(obj)
-	if effReflect.Kind() == reflect.Ptr && effReflect.Elem().Kind() == reflect.Struct {
-		field := strings.ReplaceAll(arr[0], "\a", ".")
-		if len(arr) == 1 {
-			return setProperty(obj, field, value)
+	for _, seg := range segments {
+		if seg.fansOut() || seg.descend {
+			return newPathError(seg, reflect.Invalid,
+				typeMismatch("wildcards, filters and recursive descent may not be used with dot.Set"))
 		}
+	}
 
-		fieldVal := reflect.ValueOf(obj).Elem().FieldByName(field)
-		fieldType := reflect.TypeOf(fieldVal.Interface())
-
-		// missing maps must be allocated with MakeMap
-		if fieldType.Kind() == reflect.Map && fieldVal.IsNil() {
-			newMap := reflect.MakeMap(fieldType).Interface()
-			if err := setProperty(obj, field, newMap); err != nil {
-				return err
-			}
-			return Set(newMap, strings.Join(arr[1:], "."), value)
+	root := reflect.ValueOf(obj)
+	switch root.Kind() {
+	case reflect.Map:
+	case reflect.Slice:
+	case reflect.Ptr:
+		if root.IsNil() {
+			return newPathError(segments[0], root.Kind(), notAddressable("obj may not be nil for dot.Set"))
 		}
+	default:
+		return newPathError(segments[0], root.Kind(), notAddressable("object must be a pointer to a struct"))
+	}
 
-		return Set(fieldVal.Addr().Interface(), strings.Join(arr[1:], "."), value)
+	updated, err := setValue(root, root.Type(), segments, value, a.options)
+	if err != nil {
+		return err
 	}
 
-	var err error
-	var key string
-	var fullMap map[string]interface{}
-	var effectiveObj = obj
-	var deepestSetObj = obj
-
-	var tempObj interface{}
-	var deepestSetPathIndex int
-	last, arr := arr[len(arr)-1], arr[:len(arr)-1]
-	last = strings.ReplaceAll(last, "\a", ".")
-
-	// get each level of property, all the way down to the leaf
-	for deepestSetPathIndex, key = range arr {
-		tempObj, err = getProperty(effectiveObj, strings.ReplaceAll(key, "\a", "\\."))
-		if err != nil {
-			break
-		}
+	// a slice passed by value can't be given a new backing array
+	if root.Kind() == reflect.Slice && (updated.Len() != root.Len() || updated.Pointer() != root.Pointer()) {
+		return newPathError(segments[0], root.Kind(),
+			notAddressable("slice must be passed as a pointer to append to or grow it"))
+	}
+	return nil
+}
+
+// setValue applies value at the location given by segments within v, where v is a value (possibly invalid, when missing)
+// of type t.  The updated v is returned, which the caller must store back into wherever v came from, as maps and
+// slices may have been allocated and structs may have been copied.  Errors are *PathErrors.
+func setValue(v reflect.Value, t reflect.Type, segments []segment, value interface{}, o *options) (reflect.Value, error) {
+	updated, err := setSegment(v, t, segments, value, o)
+	if err != nil {
+		return updated, newPathError(segments[0], containerKind(v, t), err)
+	}
+	return updated, nil
+}
+
+// setSegment does the work of setValue for segments[0], leaving its errors for setValue to wrap
+func setSegment(v reflect.Value, t reflect.Type, segments []segment, value interface{}, o *options) (reflect.Value, error) {
+	seg := segments[0]
+	key := seg.key
 
-		if tempObj == nil {
-			effectiveObj = nil
-			break
+	// work on whatever an interface holds, allocating a container if it holds nothing
+	if t.Kind() == reflect.Interface {
+		if !v.IsValid() || v.IsNil() {
+			v = newContainer(seg)
+		} else {
+			v = v.Elem()
 		}
+		t = v.Type()
+	} else if !v.IsValid() {
+		v = reflect.Zero(t)
+	}
 
-		effectiveObj = tempObj
-		deepestSetObj = tempObj
+	if seg.kind == appendSegment && v.Kind() != reflect.Slice && v.Kind() != reflect.Array && v.Kind() != reflect.Ptr {
+		return v, typeMismatch("cannot append to a value of kind " + v.Kind().String())
 	}
 
-	// if we need to allocate all the way down to the object
-	if effectiveObj == nil {
-		if err := setProperty(deepestSetObj, key, make(map[string]interface{})); err != nil {
-			return err
+	switch v.Kind() {
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return v, typeMismatch("cannot set property " + key + " on a map without string keys")
+		}
+
+		if v.IsNil() {
+			v = reflect.MakeMap(t)
 		}
+		mapKey := reflect.ValueOf(key).Convert(t.Key())
 
-		innerProp, err := getProperty(deepestSetObj, key)
+		// write to an existing key differing only in case, rather than adding another
+		if o.caseMode == caseInsensitive && !v.MapIndex(mapKey).IsValid() {
+			existing, ok, err := foldMapKey(v, key)
+			if err != nil {
+				return v, err
+			}
+			if ok {
+				mapKey = existing
+			}
+		}
+
+		var child reflect.Value
+		var err error
+		if len(segments) == 1 {
+			child, err = valueFor(value, t.Elem(), key, o)
+		} else {
+			child, err = setValue(v.MapIndex(mapKey), t.Elem(), segments[1:], value, o)
+		}
 		if err != nil {
-			return err
+			return v, err
 		}
 
-		propPath := strings.Split(prop, ".")
-		return Set(innerProp, strings.Join(propPath[deepestSetPathIndex+1:], "."), value)
-	}
+		v.SetMapIndex(mapKey, child)
+		return v, nil
+	case reflect.Slice, reflect.Array:
+		if !v.CanAddr() && v.Kind() == reflect.Array {
+			v = addressable(v)
+		}
+
+		if err := checkPointerIndex(seg, v.Len(), true); err != nil {
+			return v, err
+		}
+
+		var i int
+		var err error
+		if seg.kind == appendSegment || (seg.pointer && key == "-") {
+			if v.Kind() == reflect.Array {
+				return v, typeMismatch("cannot append to an array")
+			}
+			v = reflect.Append(v, reflect.Zero(t.Elem()))
+			i = v.Len() - 1
+		} else if i, err = resolveIndex(key, v.Len()); err != nil {
+			rangeErr, ok := err.(*IndexOutOfRangeError)
+			if !ok || !o.growSlices || v.Kind() == reflect.Array || rangeErr.Index < 0 {
+				return v, err
+			}
+
+			// pad the slice with zero values up to and including the requested index
+			i = rangeErr.Index
+			v = reflect.AppendSlice(v, reflect.MakeSlice(t, i+1-v.Len(), i+1-v.Len()))
+		}
+
+		elem := v.Index(i)
+		return v, setElem(elem, segments, value, o)
+	case reflect.Ptr:
+		// allocate whatever a nil pointer should point to, so Set can continue through it
+		if v.IsNil() {
+			v = reflect.New(t.Elem())
+		}
 
-	if fullMap != nil {
-		err := setProperty(effectiveObj, last, value)
+		// the pointer itself doesn't change, only what it points to
+		elem := v.Elem()
+		updated, err := setValue(elem, elem.Type(), segments, value, o)
 		if err != nil {
-			return err
+			return v, err
+		}
+		elem.Set(updated)
+		return v, nil
+	case reflect.Struct:
+		if !v.CanAddr() {
+			v = addressable(v)
 		}
 
-		b, err := json.Marshal(fullMap)
+		f, err := findField(t, key, o)
 		if err != nil {
-			return err
+			return v, err
 		}
 
-		if err := json.Unmarshal(b, &obj); err != nil {
-			return err
+		if f.readOnly {
+			return v, &ReadOnlyError{Field: f.goName}
 		}
 
-		return nil
+		field, err := settableFieldValue(v, f.index)
+		if err != nil {
+			return v, err
+		}
+		if !field.CanSet() {
+			return v, notAddressable("Cannot set " + f.goName + " field value")
+		}
+		return v, setElem(field, segments, value, o)
 	}
 
-	return setProperty(effectiveObj, last, value)
+	return v, typeMismatch("cannot set property " + key + " on a value of kind " + v.Kind().String())
 }
 
-func setProperty(obj interface{}, prop string, val interface{}) error {
-	if reflect.TypeOf(obj).Kind() == reflect.Map {
+// ReadOnlyError is returned when Set is asked to write to, or through, a struct field tagged `dot:",readonly"`.  Field
+// is the Go name of the field.
+type ReadOnlyError struct {
+	Field string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return "field " + e.Field + " is read-only"
+}
 
-		value := reflect.ValueOf(obj)
-		value.SetMapIndex(reflect.ValueOf(prop), reflect.ValueOf(val))
-		return nil
+// Is makes errors.Is match the error to ErrNotAddressable
+func (e *ReadOnlyError) Is(target error) bool {
+	return target == ErrNotAddressable
+}
+
+// setElem applies value at segments[1:] within elem, which must be settable and is what segments[0] addressed
+func setElem(elem reflect.Value, segments []segment, value interface{}, o *options) error {
+	var updated reflect.Value
+	var err error
+	if len(segments) == 1 {
+		updated, err = valueFor(value, elem.Type(), segments[0].key, o)
+	} else {
+		updated, err = setValue(elem, elem.Type(), segments[1:], value, o)
+	}
+	if err != nil {
+		return err
 	}
 
-	if reflect.TypeOf(obj).Kind() != reflect.Ptr {
-		return errors.New("object must be a pointer to a struct")
+	elem.Set(updated)
+	return nil
+}
+
+// valueFor prepares value to be stored at prop, which has type t, converting it if the options or a registered
+// Coercer allow
+func valueFor(value interface{}, t reflect.Type, prop string, o *options) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(t), nil
+	}
+
+	val := reflect.ValueOf(value)
+	if !val.Type().AssignableTo(t) {
+		if o.convert {
+			return convertValue(val, t)
+		}
+		if converted, registered, err := registeredCoercion(value, t); registered {
+			return converted, err
+		}
+		return val, typeMismatch("value of type " + val.Type().String() + " cannot be set on property " + prop +
+			" of type " + t.String())
 	}
-	prop = strings.Title(prop)
+	return val, nil
+}
+
+// newContainer allocates the node Set creates when it must travel through a missing node to reach seg
+func newContainer(seg segment) reflect.Value {
+	if seg.kind == appendSegment {
+		return reflect.ValueOf([]interface{}{})
+	}
+	return reflect.ValueOf(map[string]interface{}{})
+}
+
+// containerKind returns the kind of the container v (of type t) that a segment is applied to, looking inside interfaces
+func containerKind(v reflect.Value, t reflect.Type) reflect.Kind {
+	if !v.IsValid() {
+		return t.Kind()
+	}
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		return v.Elem().Kind()
+	}
+	return v.Kind()
+}
 
-	return reflections.SetField(obj, prop, val)
+// addressable returns a settable copy of v
+func addressable(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
 }
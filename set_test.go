@@ -196,3 +196,151 @@ func TestBadCharsNoPeriodAtEnd(t *testing.T) {
 		t.Fatal("mm != MLL")
 	}
 }
+
+func TestSet_SliceIndex(t *testing.T) {
+	type Post struct {
+		Tags []string
+	}
+
+	p := Post{Tags: []string{"a", "b", "c", "d"}}
+	if err := Set(&p, "Tags.3", "z"); err != nil {
+		t.Fatal(err)
+	}
+	if p.Tags[3] != "z" {
+		t.Fatal("Tags.3 was not set")
+	}
+
+	if err := Set(&p, "Tags.4", "y"); err == nil {
+		t.Fatal("did not get an error setting beyond the end of a slice")
+	}
+
+	if err := Set(&p, "Tags.1", 5); err == nil {
+		t.Fatal("did not get an error setting an int into a string slice")
+	}
+
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": 1},
+		},
+	}
+	if err := Set(data, "items.0.id", 2); err != nil {
+		t.Fatal(err)
+	}
+	if GetInt64(data, "items.0.id") != 2 {
+		t.Fatal("items.0.id was not set")
+	}
+}
+
+func TestSet_SliceAppend(t *testing.T) {
+	type Post struct {
+		Tags []string
+	}
+
+	p := Post{}
+	if err := Set(&p, "Tags.+", "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(&p, "Tags.[]", "second"); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Tags) != 2 || p.Tags[0] != "first" || p.Tags[1] != "second" {
+		t.Fatal("Tags were not appended")
+	}
+
+	// missing nodes followed by an append segment are allocated as slices
+	doc := make(map[string]interface{})
+	if err := Set(doc, "order.lines.+.sku", "A1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(doc, "order.lines.+.sku", "B2"); err != nil {
+		t.Fatal(err)
+	}
+	if GetString(doc, "order.lines.1.sku") != "B2" {
+		t.Fatal("order.lines.1.sku was not B2")
+	}
+	if _, ok := doc["order"].(map[string]interface{})["lines"].([]interface{}); !ok {
+		t.Fatal("order.lines was not allocated as a slice")
+	}
+
+	// a slice passed by value can't grow
+	tags := []string{"a"}
+	if err := Set(tags, "+", "b"); err == nil {
+		t.Fatal("did not get an error appending to a slice passed by value")
+	}
+	if err := Set(&tags, "+", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 2 {
+		t.Fatal("slice pointer was not appended to")
+	}
+}
+
+func TestSet_GrowSlices(t *testing.T) {
+	type Grid struct {
+		Cells []int
+	}
+
+	g := Grid{Cells: []int{1}}
+	if err := Set(&g, "Cells.3", 4, GrowSlices()); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Cells) != 4 || g.Cells[0] != 1 || g.Cells[1] != 0 || g.Cells[3] != 4 {
+		t.Fatal("Cells was not grown and padded with zero values")
+	}
+
+	// negative indices never grow
+	if err := Set(&g, "Cells.-6", 4, GrowSlices()); err == nil {
+		t.Fatal("did not get an error for a negative index beyond the start of the slice")
+	}
+}
+
+func TestSet_AllocatesNilPointers(t *testing.T) {
+	type Geo struct {
+		Lat float64
+	}
+	type Address struct {
+		City string
+		Geo  **Geo
+	}
+	type User struct {
+		Address *Address
+		Tags    *[]string
+		Extra   *map[string]interface{}
+	}
+
+	u := User{}
+	if err := Set(&u, "Address.City", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if u.Address == nil || u.Address.City != "x" {
+		t.Fatal("Address was not allocated")
+	}
+
+	// chains of pointers are allocated link by link
+	if err := Set(&u, "Address.Geo.Lat", 1.5); err != nil {
+		t.Fatal(err)
+	}
+	if u.Address.Geo == nil || *u.Address.Geo == nil || (*u.Address.Geo).Lat != 1.5 {
+		t.Fatal("pointer chain was not allocated")
+	}
+
+	// an existing pointer is written through rather than replaced
+	address := u.Address
+	if err := Set(&u, "Address.City", "y"); err != nil || u.Address != address || address.City != "y" {
+		t.Fatal("existing pointer was not written through:", err)
+	}
+
+	if err := Set(&u, "Tags.+", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if u.Tags == nil || len(*u.Tags) != 1 || (*u.Tags)[0] != "a" {
+		t.Fatal("pointer to slice was not allocated")
+	}
+
+	if err := Set(&u, "Extra.key", "value"); err != nil {
+		t.Fatal(err)
+	}
+	if u.Extra == nil || (*u.Extra)["key"] != "value" {
+		t.Fatal("pointer to map was not allocated")
+	}
+}
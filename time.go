@@ -0,0 +1,174 @@
+package dot
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// epochMillisThreshold separates Unix times in seconds from those in milliseconds.  As seconds, it is in the year
+// 33658; as milliseconds, it is in 2001.
+const epochMillisThreshold = 1e12
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// GetTime does what Get does, except it continues through props until
+// it not only gets a non-nil value, but also gets something that can be
+// cast/coerced to a time.Time, as CoerceTime does with the given layouts.
+// Will return the zero time.Time if the property doesn't exist or could
+// not be coerced.
+func GetTime(obj interface{}, layouts []string, props ...string) time.Time {
+	return std.GetTime(obj, layouts, props...)
+}
+
+// GetTime does what the package-level GetTime does, using the Accessor's options
+func (a *Accessor) GetTime(obj interface{}, layouts []string, props ...string) time.Time {
+	v, _, _ := getFirst(a, obj, props, coerceWith(func(v interface{}) (time.Time, bool) {
+		return CoerceTime(v, layouts...)
+	}))
+	return v
+}
+
+// CoerceTime will make a best-effort to convert the provided argument to a time.Time.  It supports time.Time and
+// *time.Time, strings or []byte in RFC 3339 format or in any of the given layouts (tried in order), and Unix times as
+// numbers or numeric strings.  Unix times are in seconds, or in milliseconds if they are too large to be seconds in
+// any year before 33658, and are returned in UTC.  Types whose marshaled forms are any of those are supported too (see
+// RegisterCoercion).
+func CoerceTime(obj interface{}, layouts ...string) (time.Time, bool) {
+	if t, registered, ok := registeredAs[time.Time](obj); registered {
+		return t, ok
+	}
+
+	asTime, ok := obj.(time.Time)
+	if ok {
+		return asTime, true
+	}
+
+	asTimePtr, ok := obj.(*time.Time)
+	if ok && asTimePtr != nil {
+		return *asTimePtr, true
+	}
+
+	asString, ok := obj.(string)
+	if ok {
+		return parseTime(asString, layouts)
+	}
+
+	asBytes, ok := obj.([]byte)
+	if ok {
+		return parseTime(string(asBytes), layouts)
+	}
+
+	if t, ok := epochTime(obj); ok {
+		return t, true
+	}
+
+	var marshaled time.Time
+	ok = coerceMarshaled(obj, func(v interface{}) bool {
+		marshaled, ok = CoerceTime(v, layouts...)
+		return ok
+	})
+	return marshaled, ok
+}
+
+// parseTime reads the strings CoerceTime accepts
+func parseTime(s string, layouts []string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range append([]string{time.RFC3339Nano}, layouts...) {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return epochTime(s)
+}
+
+// epochTime interprets obj, which may be any number or a numeric string, as a Unix time in seconds or milliseconds
+func epochTime(obj interface{}) (time.Time, bool) {
+	val := reflect.ValueOf(obj)
+	if !isNumeric(val) && !isText(val) {
+		return time.Time{}, false
+	}
+
+	// integers (including integer strings) are read exactly, rather than through a float64
+	if val.Kind() != reflect.Float32 && val.Kind() != reflect.Float64 {
+		if i, ok := CoerceInt64(textOrValue(val)); ok {
+			if i >= epochMillisThreshold || i <= -epochMillisThreshold {
+				return time.UnixMilli(i).UTC(), true
+			}
+			return time.Unix(i, 0).UTC(), true
+		}
+	}
+
+	f, ok := CoerceFloat64(textOrValue(val))
+	if !ok || math.IsNaN(f) || math.IsInf(f, 0) {
+		return time.Time{}, false
+	}
+	if math.Abs(f) >= epochMillisThreshold {
+		f /= 1000
+	}
+	if f >= math.MaxInt64 || f < math.MinInt64 {
+		return time.Time{}, false
+	}
+
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC(), true
+}
+
+// GetDuration does what Get does, except it continues through props until
+// it not only gets a non-nil value, but also gets something that can be
+// cast/coerced to a time.Duration, as CoerceDuration does.  Will return 0
+// if the property doesn't exist or could not be coerced.
+func GetDuration(obj interface{}, props ...string) time.Duration {
+	return std.GetDuration(obj, props...)
+}
+
+// GetDuration does what the package-level GetDuration does, using the Accessor's options
+func (a *Accessor) GetDuration(obj interface{}, props ...string) time.Duration {
+	v, _, _ := getFirst(a, obj, props, coerceWith(CoerceDuration))
+	return v
+}
+
+// CoerceDuration will make a best-effort to convert the provided argument to a time.Duration.  It supports
+// time.Duration and *time.Duration, strings or []byte that time.ParseDuration accepts (such as "1m30s"), and numbers
+// or numeric strings, which are taken as seconds.  Types whose marshaled forms are any of those are supported too (see
+// RegisterCoercion).
+func CoerceDuration(obj interface{}) (time.Duration, bool) {
+	if d, registered, ok := registeredAs[time.Duration](obj); registered {
+		return d, ok
+	}
+
+	asDuration, ok := obj.(time.Duration)
+	if ok {
+		return asDuration, true
+	}
+
+	asDurationPtr, ok := obj.(*time.Duration)
+	if ok && asDurationPtr != nil {
+		return *asDurationPtr, true
+	}
+
+	val := reflect.ValueOf(obj)
+	if isText(val) {
+		if d, err := time.ParseDuration(strings.TrimSpace(textOf(val))); err == nil {
+			return d, true
+		}
+	} else if !isNumeric(val) {
+		var marshaled time.Duration
+		ok = coerceMarshaled(obj, func(v interface{}) bool {
+			marshaled, ok = CoerceDuration(v)
+			return ok
+		})
+		return marshaled, ok
+	}
+
+	seconds, ok := CoerceFloat64(textOrValue(val))
+	if !ok || math.IsNaN(seconds) || math.Abs(seconds) >= math.MaxInt64/float64(time.Second) {
+		return 0, false
+	}
+	return time.Duration(math.Round(seconds * float64(time.Second))), true
+}
@@ -0,0 +1,129 @@
+package dot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoerceTime(t *testing.T) {
+	want := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	for _, value := range []interface{}{
+		want,
+		&want,
+		"2021-03-04T05:06:07Z",
+		[]byte("2021-03-04T05:06:07Z"),
+		"2021-03-04T07:06:07+02:00",
+		want.Unix(),
+		int32(want.Unix()),
+		float64(want.Unix()),
+		want.UnixMilli(),
+		float64(want.UnixMilli()),
+		"1614834367",
+		"1614834367000",
+	} {
+		got, ok := CoerceTime(value)
+		if !ok || !got.Equal(want) {
+			t.Error("did not coerce", value, "to", want, "got", got)
+		}
+	}
+
+	if got, ok := CoerceTime(1614834367.5); !ok || got.Sub(want) != 500*time.Millisecond {
+		t.Error("fractional seconds were not kept, got", got)
+	}
+
+	if got, ok := CoerceTime("04/03/2021", "02/01/2006"); !ok || !got.Equal(time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Error("custom layout was not used, got", got)
+	}
+
+	var nilTime *time.Time
+	for _, value := range []interface{}{nil, nilTime, "yesterday", true, []string{}} {
+		if _, ok := CoerceTime(value); ok {
+			t.Error("coerced", value, "to a time")
+		}
+	}
+}
+
+func TestGetTime(t *testing.T) {
+	data := map[string]interface{}{
+		"bad":     "soon",
+		"created": "2021-03-04 05:06:07",
+		"updated": int64(1614834367),
+	}
+
+	layouts := []string{"2006-01-02 15:04:05"}
+	if got := GetTime(data, layouts, "missing", "bad", "created"); got.Year() != 2021 || got.Hour() != 5 {
+		t.Error("did not fall back to created, got", got)
+	}
+	if got := GetTime(data, nil, "created", "updated"); got.Unix() != 1614834367 {
+		t.Error("did not fall back to updated without the layout, got", got)
+	}
+	if got := GetTime(data, nil, "bad"); !got.IsZero() {
+		t.Error("did not get the zero time, got", got)
+	}
+}
+
+func TestCoerceDuration(t *testing.T) {
+	want := 90 * time.Second
+
+	for _, value := range []interface{}{want, &want, "1m30s", []byte("90s"), 90, int64(90), 90.0, "90", uint8(90)} {
+		if got, ok := CoerceDuration(value); !ok || got != want {
+			t.Error("did not coerce", value, "to", want, "got", got)
+		}
+	}
+
+	if got, ok := CoerceDuration(0.25); !ok || got != 250*time.Millisecond {
+		t.Error("fractional seconds were not kept, got", got)
+	}
+
+	for _, value := range []interface{}{nil, "a while", true, 1e20} {
+		if _, ok := CoerceDuration(value); ok {
+			t.Error("coerced", value, "to a duration")
+		}
+	}
+}
+
+func TestGetDuration(t *testing.T) {
+	data := map[string]interface{}{"timeout": "forever", "legacyTimeout": 30}
+	if GetDuration(data, "timeout", "legacyTimeout") != 30*time.Second {
+		t.Error("did not fall back to legacyTimeout")
+	}
+	if GetDuration(data, "timeout") != 0 {
+		t.Error("did not get 0 for a value that can't be coerced")
+	}
+}
+
+func TestGetAs_TimeAndDuration(t *testing.T) {
+	data := map[string]interface{}{
+		"created": "2020-01-01T00:00:00Z",
+		"timeout": "1m30s",
+		"retry":   2,
+		"bad":     "soon",
+	}
+
+	created, err := GetAs[time.Time](data, "created")
+	if err != nil || !created.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("GetAs did not coerce a time, got", created, err)
+	}
+	if timeout, err := GetAs[time.Duration](data, "timeout"); err != nil || timeout != 90*time.Second {
+		t.Error("GetAs did not coerce a duration, got", timeout, err)
+	}
+	if retry, err := GetAs[time.Duration](data, "retry"); err != nil || retry != 2*time.Second {
+		t.Error("GetAs did not read a number as seconds, got", retry, err)
+	}
+	if _, err := GetAs[time.Time](data, "bad"); err == nil {
+		t.Error("expected an error for a value that isn't a time")
+	}
+
+	var config struct {
+		Timeout *time.Duration
+		Created time.Time
+	}
+	converting := New(WithConversion())
+	if err := converting.Set(&config, "Timeout", "1m30s"); err != nil || *config.Timeout != 90*time.Second {
+		t.Error("converting Set did not coerce a duration:", err)
+	}
+	if err := converting.Set(&config, "Created", "2020-01-01T00:00:00Z"); err != nil || !config.Created.Equal(created) {
+		t.Error("converting Set did not coerce a time:", err)
+	}
+}